@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/linera-protocol/examples/universal-solver/client/solver"
+	"github.com/linera-protocol/examples/universal-solver/client/solver/nftid"
+)
+
+// cliCommands maps each nftctl subcommand to its handler. Handlers that take
+// params read them as JSON from -file (or stdin when -file is omitted);
+// get-nfts and next-id take none. Every handler
+// returns the value to print as its JSON response, following the same
+// flag.NewFlagSet-per-verb shape as the Cosmos SDK nft module CLI. This
+// lets list-nft, list-for-sale, post-tx-hash, publish-image, get-nfts,
+// and next-id run from a shell script or cron job without a server
+// listening on port 3000.
+var cliCommands = map[string]func(args []string) (interface{}, error){
+	"list-nft":      cliListNFT,
+	"list-for-sale": cliListNFTForSale,
+	"post-tx-hash":  cliPostTxHash,
+	"publish-image": cliPublishImage,
+	"get-nfts":      cliGetNFTs,
+	"next-id":       cliNextNFTID,
+}
+
+// runCLI dispatches args (the positional args left after the global
+// -mode=cli flags) to the matching subcommand in cliCommands, printing
+// its JSON result to stdout and exiting non-zero on error.
+func runCLI(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: client -mode=cli <command> [-file params.json]")
+		fmt.Fprintln(os.Stderr, "commands: list-nft, list-for-sale, post-tx-hash, publish-image, get-nfts, next-id")
+		os.Exit(1)
+	}
+
+	cmd, ok := cliCommands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", args[0])
+		os.Exit(1)
+	}
+
+	result, err := cmd(args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(result); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// readCLIParams parses a -file flag for verb out of args and decodes the
+// JSON params it points at into v, reading from stdin when -file isn't
+// given.
+func readCLIParams(verb string, args []string, v interface{}) error {
+	fs := flag.NewFlagSet(verb, flag.ExitOnError)
+	file := fs.String("file", "", "path to a JSON params file (defaults to stdin)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return fmt.Errorf("opening -file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("parsing params: %w", err)
+	}
+	return nil
+}
+
+func cliPostTxHash(args []string) (interface{}, error) {
+	var p PostTxHashParams
+	if err := readCLIParams("post-tx-hash", args, &p); err != nil {
+		return nil, err
+	}
+	return processTxHash(p)
+}
+
+func cliPublishImage(args []string) (interface{}, error) {
+	var p solver.BlobHashParams
+	if err := readCLIParams("publish-image", args, &p); err != nil {
+		return nil, err
+	}
+
+	blobHash, err := solverClient.PublishDataBlob(p.ChainId, p.ImageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error publishing blob: %w", err)
+	}
+
+	return map[string]interface{}{
+		"status":   "success",
+		"message":  "Blob is published successfully",
+		"blobHash": blobHash,
+	}, nil
+}
+
+func cliListNFT(args []string) (interface{}, error) {
+	var p solver.ListNFTParams
+	if err := readCLIParams("list-nft", args, &p); err != nil {
+		return nil, err
+	}
+
+	// nftId must be a canonical "{class_id}/{id}" identifier (Cosmos SDK
+	// ADR-043 style) before it reaches solverClient.
+	if _, _, err := nftid.ParseCanonical(p.NftId); err != nil {
+		return nil, fmt.Errorf("invalid nftId: %w", err)
+	}
+
+	blobHash, err := solverClient.ListNFT(p)
+	if err != nil {
+		return nil, fmt.Errorf("error listing NFT: %w", err)
+	}
+
+	return map[string]interface{}{
+		"status":   "success",
+		"message":  "NFT listed successfully",
+		"blobHash": blobHash,
+	}, nil
+}
+
+// listForSaleParams mirrors the JSON body handleListNFTForSale accepts
+// over HTTP.
+type listForSaleParams struct {
+	Owner      string `json:"owner"`
+	ChainId    string `json:"chainId"`
+	TokenId    string `json:"tokenId"`
+	Price      string `json:"price"`
+	NftId      string `json:"nftId"`
+	ChainOwner string `json:"chainOwner"`
+}
+
+func cliListNFTForSale(args []string) (interface{}, error) {
+	var p listForSaleParams
+	if err := readCLIParams("list-for-sale", args, &p); err != nil {
+		return nil, err
+	}
+
+	// nftId and tokenId must be canonical "{class_id}/{id}" identifiers
+	// (Cosmos SDK ADR-043 style) before they reach solverClient.
+	if _, _, err := nftid.ParseCanonical(p.NftId); err != nil {
+		return nil, fmt.Errorf("invalid nftId: %w", err)
+	}
+	if _, _, err := nftid.ParseCanonical(p.TokenId); err != nil {
+		return nil, fmt.Errorf("invalid tokenId: %w", err)
+	}
+
+	data, err := solverClient.ListNftForSale(p.Owner, p.ChainId, p.TokenId, p.Price, p.NftId, p.ChainOwner)
+	if err != nil {
+		return nil, fmt.Errorf("error listing NFT for sale: %w", err)
+	}
+
+	return map[string]interface{}{"status": "success", "data": data}, nil
+}
+
+func cliGetNFTs(args []string) (interface{}, error) {
+	nfts, err := solverClient.GetAllNFTs()
+	if err != nil {
+		return nil, fmt.Errorf("error getting NFTs: %w", err)
+	}
+	return map[string]interface{}{"status": "success", "data": nfts}, nil
+}
+
+func cliNextNFTID(args []string) (interface{}, error) {
+	currentID, err := solverClient.GetCurrentTokenID()
+	if err != nil {
+		return nil, fmt.Errorf("error getting next NFT ID: %w", err)
+	}
+	return map[string]interface{}{
+		"status": "success",
+		"data": map[string]uint64{
+			"currentId": currentID,
+			"nextId":    currentID + 1,
+		},
+	}, nil
+}
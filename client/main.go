@@ -2,25 +2,47 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
-	"math/big"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/linera-protocol/examples/universal-solver/client/solver"
+	"github.com/linera-protocol/examples/universal-solver/client/solver/nftid"
 )
 
+// badRequestError marks a processTxHash failure as the caller's fault
+// (missing/invalid parameters) rather than a downstream RPC/solver
+// failure, so handlePostTxHash can map it to 400 instead of 500 without
+// processTxHash itself depending on net/http.
+type badRequestError struct{ err error }
+
+func (e *badRequestError) Error() string { return e.err.Error() }
+func (e *badRequestError) Unwrap() error { return e.err }
+
+func badRequestf(format string, args ...interface{}) error {
+	return &badRequestError{fmt.Errorf(format, args...)}
+}
+
 var (
 	solverClient *solver.Client
-	SolanaRPC    string
-	EthereumRPC  string
-	chainToToken = map[string]string{
-		"ethereum": "ETH",
-		"solana":   "SOL",
-	}
+
+	// chainRPCs holds the RPC endpoint configured for each registered
+	// solver.ChainAdapter, keyed by its Name(). Ethereum and Solana keep
+	// their existing -ethereum-url/-solana-url flags; every other
+	// adapter registered with solver.RegisterAdapter gets a
+	// "--<chain>-url" flag here automatically, so adding a chain never
+	// touches initFlags again.
+	chainRPCs = map[string]string{}
+
+	// runMode is "server" (default, runs the HTTP API) or "cli" (runs a
+	// single nftctl subcommand; see cli.go).
+	runMode string
 )
 
 func init() {
@@ -36,6 +58,19 @@ func initFlags() {
 	ethereumRPCURL := flag.String("ethereum-url", getEnvOrDefault("ETHEREUM_RPC", "http://localhost:8545"), "Ethereum RPC endpoint")
 	nftAddress := flag.String("nft-address", getEnvOrDefault("NFT_ADDRESS", ""), "NFT contract address")
 	seedPhrase := flag.String("seed-phrase", "", "Seed phrase for deriving chain keys (required)")
+	mode := flag.String("mode", "server", `run mode: "server" (default, runs the HTTP API) or "cli" (runs a single nftctl subcommand)`)
+
+	// Every chain adapter beyond ethereum/solana (which keep the flags
+	// above for backwards compatibility) gets a "--<chain>-url" flag
+	// registered here instead of requiring an edit to this function.
+	extraChainURLs := map[string]*string{}
+	for _, name := range solver.AdapterNames() {
+		if name == "ethereum" || name == "solana" {
+			continue
+		}
+		envKey := strings.ToUpper(name) + "_RPC"
+		extraChainURLs[name] = flag.String(name+"-url", getEnvOrDefault(envKey, ""), fmt.Sprintf("%s RPC endpoint", name))
+	}
 
 	// Only parse flags if not running tests
 	if !testing.Testing() {
@@ -58,22 +93,32 @@ func initFlags() {
 			fmt.Println("        NFT contract address")
 			fmt.Println("  -seed-phrase string")
 			fmt.Println("        Seed phrase for deriving chain keys (required)")
+			fmt.Println("  -mode string")
+			fmt.Println("        \"server\" (default) or \"cli\"")
 			os.Exit(1)
 		}
 	}
 
+	runMode = *mode
+
 	// Initialize solver client with provided URLs
 	solverClient = solver.NewClient(*solverURL, *nonFungibleURL, *lineraURL)
 
 	// Initialize RPC endpoints and NFT address
 	solver.InitConfig(*ethereumRPCURL, *solanaRPCURL, *nftAddress)
 
+	chainRPCs["ethereum"] = *ethereumRPCURL
+	chainRPCs["solana"] = *solanaRPCURL
+	for name, url := range extraChainURLs {
+		chainRPCs[name] = *url
+	}
+
 	// Initialize keys with seed phrase
 	if err := solver.InitKeys(*seedPhrase); err != nil {
 		log.Fatalf("Failed to initialize keys: %v", err)
 	}
 
-	solver.InitLogger()
+	solver.InitLogger(solver.LogConfig{Level: solver.LevelInfo})
 	// Log configuration
 	log.Printf("Initialized with:")
 	log.Printf("  Solver URL: %s", *solverURL)
@@ -111,11 +156,18 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 }
 
 func main() {
+	if runMode == "cli" {
+		runCLI(flag.Args())
+		return
+	}
+
 	// Define routes with CORS middleware
 	http.HandleFunc("/post_tx_hash", corsMiddleware(handlePostTxHash))
 	http.HandleFunc("/list_nft", corsMiddleware(handleListNFT))
 	http.HandleFunc("/list_nft_for_sale", corsMiddleware(handleListNFTForSale))
 	http.HandleFunc("/nfts", corsMiddleware(handleGetNFTs))
+	http.HandleFunc("/nfts/balance/", corsMiddleware(handleNFTBalance))
+	http.HandleFunc("/nfts/owner/", corsMiddleware(handleNFTsOfOwner))
 	http.HandleFunc("/publish_image", corsMiddleware(handleBlobHash))
 	http.HandleFunc("/next_nft_id", corsMiddleware(handleNextNFTID))
 	http.HandleFunc("/ws", corsMiddleware(handleWebSocket))
@@ -128,176 +180,179 @@ func main() {
 	}
 }
 
-// Update the handlePostTxHash function
-func handlePostTxHash(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var (
-		tx  interface{}
-		err error
-	)
-
-	// Get parameters from query params
-	txHash := r.URL.Query().Get("txHash")
-	chain := r.URL.Query().Get("chain")
-	toToken := r.URL.Query().Get("toToken")
-	destinationAddress := r.URL.Query().Get("destinationAddress")
-
-	// Get additional transfer parameters
-	sourceOwner := r.URL.Query().Get("sourceOwner")
-	tokenId := r.URL.Query().Get("tokenId")
-	blobHash := r.URL.Query().Get("blobHash")
+// PostTxHashParams carries the /post_tx_hash parameters, read from query
+// params over HTTP or from JSON for the "post-tx-hash" CLI subcommand.
+type PostTxHashParams struct {
+	TxHash             string `json:"txHash"`
+	Chain              string `json:"chain"`
+	ToToken            string `json:"toToken"`
+	DestinationAddress string `json:"destinationAddress"`
+	SourceOwner        string `json:"sourceOwner"`
+	TokenId            string `json:"tokenId"`
+	BlobHash           string `json:"blobHash"`
+	TargetChainId      string `json:"targetChainId"`
+	TargetOwner        string `json:"targetOwner"`
+	NftId              string `json:"nftId"`
+}
 
-	if err != nil {
-		http.Error(w, "Invalid tokenId", http.StatusBadRequest)
-		return
+// processTxHash implements /post_tx_hash: it fetches p.TxHash from p.Chain
+// and, if p.ToToken/p.DestinationAddress are set, extracts the amount
+// moved and executes the swap/transfer. It's shared by the HTTP handler
+// and the "post-tx-hash" CLI subcommand so the two stay in lockstep.
+func processTxHash(p PostTxHashParams) (map[string]interface{}, error) {
+	if p.TxHash == "" {
+		return nil, badRequestf("txHash parameter is required")
 	}
-	targetChainId := r.URL.Query().Get("targetChainId")
-	targetOwner := r.URL.Query().Get("targetOwner")
-	nftId := r.URL.Query().Get("nftId")
-
-	// Validate required parameters
-	if txHash == "" {
-		http.Error(w, "txHash parameter is required", http.StatusBadRequest)
-		return
+	if p.Chain == "" {
+		return nil, badRequestf("chain parameter is required")
 	}
 
-	if chain == "" {
-		http.Error(w, "chain parameter is required", http.StatusBadRequest)
-		return
+	// Look up the registered adapter for chain instead of branching on
+	// chain name here: adding a chain is a single solver.RegisterAdapter
+	// call rather than an edit to this function.
+	adapter, ok := solver.GetAdapter(p.Chain)
+	if !ok {
+		return nil, badRequestf("invalid chain parameter: %s", p.Chain)
 	}
-
-	// Get transaction details based on chain
-	switch chain {
-	case "solana":
-		tx, err = solverClient.GetSolanaTransaction(SolanaRPC, txHash)
-	case "ethereum":
-		tx, err = solverClient.GetEthereumTransaction(EthereumRPC, txHash)
-	default:
-		http.Error(w, "Invalid chain parameter. Must be 'solana' or 'ethereum'", http.StatusBadRequest)
-		return
+	rpc, ok := chainRPCs[p.Chain]
+	if !ok || rpc == "" {
+		return nil, badRequestf("no RPC endpoint configured for chain: %s", p.Chain)
 	}
 
+	tx, err := adapter.GetTransaction(rpc, p.TxHash)
 	if err != nil {
-		http.Error(w, "Error getting transaction: "+err.Error(), http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("error getting transaction: %w", err)
 	}
 
 	response := map[string]interface{}{
 		"status": "success",
-		"chain":  chain,
+		"chain":  p.Chain,
 		"data":   tx,
 	}
 
 	// If toToken and destinationAddress are provided, execute transfer
-	if toToken != "" && destinationAddress != "" {
+	if p.ToToken != "" && p.DestinationAddress != "" {
+		// nftId and tokenId must be canonical "{class_id}/{id}" identifiers
+		// (Cosmos SDK ADR-043 style) before they reach solverClient.
+		if _, _, err := nftid.ParseCanonical(p.NftId); err != nil {
+			return nil, badRequestf("invalid nftId: %w", err)
+		}
+		if _, _, err := nftid.ParseCanonical(p.TokenId); err != nil {
+			return nil, badRequestf("invalid tokenId: %w", err)
+		}
+
 		// Get the from token based on chain
-		fromToken, err := getTokenForChain(chain)
+		fromToken := adapter.NativeToken()
+
+		// Extract amount from transaction, preferring the token moved by
+		// an ERC-20/SPL transfer over the chain-default fromToken above.
+		amount, detectedToken, err := adapter.ExtractAmount(tx)
 		if err != nil {
-			http.Error(w, "Error getting token for chain: "+err.Error(), http.StatusInternalServerError)
-			return
+			return nil, fmt.Errorf("error extracting amount from transaction: %w", err)
+		}
+		if detectedToken != "" {
+			fromToken = detectedToken
 		}
 
-		// Extract amount from transaction
-		amount, err := extractAmountFromTx(tx)
-		if err != nil {
-			http.Error(w, "Error extracting amount from transaction: "+err.Error(), http.StatusInternalServerError)
-			return
+		// If the buyer actually paid in an ERC-20 token (rather than
+		// ExtractAmount falling back to the native value field), carry the
+		// token contract and buyer address through so
+		// ExecuteTransferMutation can confirm that specific payment before
+		// finalizing the mint/transfer.
+		var paymentTxHash, paymentTokenAddress, buyerAddress string
+		if p.Chain == "ethereum" && detectedToken != adapter.NativeToken() {
+			if txMap, ok := tx.(map[string]interface{}); ok {
+				tokenAddr, _ := txMap["to"].(string)
+				buyerAddr, _ := txMap["from"].(string)
+				if tokenAddr != "" && buyerAddr != "" {
+					paymentTxHash = p.TxHash
+					paymentTokenAddress = tokenAddr
+					buyerAddress = buyerAddr
+				}
+			}
 		}
 
 		// First calculate the swap
-		swapResult, err := solverClient.CalculateSwap(fromToken, toToken, amount)
+		swapResult, err := solverClient.CalculateSwap(fromToken, p.ToToken, amount)
 		if err != nil {
-			http.Error(w, "Error calculating swap: "+err.Error(), http.StatusInternalServerError)
-			return
+			return nil, fmt.Errorf("error calculating swap: %w", err)
 		}
 
 		// Use provided parameters or defaults
 		transferParams := solver.TransferParams{
-			SourceOwner:   sourceOwner,
-			TokenId:       tokenId,
-			TargetChainId: targetChainId,
-			TargetOwner:   targetOwner,
-			ChainOwner:    destinationAddress,
-			BuyFromToken:  fromToken,
-			ToToken:       toToken,
-			Amount:        fmt.Sprintf("%f", swapResult.ToAmount), // Use calculated amount
-			BlobHash:      blobHash,
-			NftId:         nftId,
+			SourceOwner:         p.SourceOwner,
+			TokenId:             p.TokenId,
+			TargetChainId:       p.TargetChainId,
+			TargetOwner:         p.TargetOwner,
+			ChainOwner:          p.DestinationAddress,
+			BuyFromToken:        fromToken,
+			ToToken:             p.ToToken,
+			Amount:              fmt.Sprintf("%f", swapResult.ToAmount), // Use calculated amount
+			BlobHash:            p.BlobHash,
+			NftId:               p.NftId,
+			PaymentTxHash:       paymentTxHash,
+			PaymentTokenAddress: paymentTokenAddress,
+			BuyerAddress:        buyerAddress,
 		}
 
 		// Execute transfer mutation with swap result
 		transferResp, txhash, err := solverClient.ExecuteTransferMutation(transferParams)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			return nil, err
 		}
 
 		response["transfer_result"] = transferResp.Data
 		response["swap_calculation"] = swapResult
 		response["txhash"] = txhash
+
+		solverClient.PublishEvent("nft.sold", map[string]interface{}{
+			"nftId":           p.NftId,
+			"tokenId":         p.TokenId,
+			"swapCalculation": swapResult,
+			"txhash":          txhash,
+		}, map[string]string{
+			"chainId": p.TargetChainId,
+			"owner":   p.TargetOwner,
+			"nftId":   p.NftId,
+		})
 	}
 
-	// Return response
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	return response, nil
 }
 
-// Helper function to extract amount from transaction
-func extractAmountFromTx(tx interface{}) (float64, error) {
-	switch v := tx.(type) {
-	case map[string]interface{}:
-		// For Ethereum
-		if value, ok := v["value"].(string); ok {
-			// Parse decimal string to big.Int
-			bigValue := new(big.Int)
-			if _, success := bigValue.SetString(value, 10); !success {
-				return 0, fmt.Errorf("failed to parse decimal value: %s", value)
-			}
-			// Convert from wei to ETH (divide by 10^18) and check if result fits uint64
-			weiPerEth := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
-			ethValue := new(big.Int).Div(bigValue, weiPerEth)
-			if !ethValue.IsUint64() {
-				return 0, fmt.Errorf("converted ETH value exceeds uint64 range: %s", ethValue.String())
-			}
-			flval, _ := ethValue.Float64()
-			return flval, nil
-		}
-		// For Solana
-		if result, ok := v["result"].(map[string]interface{}); ok {
-			meta := result
-			if meta, ok := meta["meta"].(map[string]interface{}); ok {
-				if preBalances, ok := meta["preBalances"].([]interface{}); ok && len(preBalances) > 0 {
-					if postBalances, ok := meta["postBalances"].([]interface{}); ok && len(postBalances) > 0 {
-						// Get the difference between pre and post balances of sender
-						preBalance := uint64(preBalances[0].(float64))
-						postBalance := uint64(postBalances[0].(float64))
-						if preBalance > postBalance {
-							// Convert from lamports to SOL (divide by 10^9)
-							lamports := preBalance - postBalance
-							solValue := float64(lamports) / 1e9
-							if solValue > float64(^uint64(0)) {
-								return 0, fmt.Errorf("converted SOL value exceeds uint64 range: %f", solValue)
-							}
-							return solValue, nil
-						}
-					}
-				}
-			}
-		}
+// Update the handlePostTxHash function
+func handlePostTxHash(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
-	return 0, fmt.Errorf("could not extract amount from transaction")
-}
 
-func getTokenForChain(chain string) (string, error) {
-	token, ok := chainToToken[chain]
-	if !ok {
-		return "", fmt.Errorf("unsupported chain: %s", chain)
+	q := r.URL.Query()
+	response, err := processTxHash(PostTxHashParams{
+		TxHash:             q.Get("txHash"),
+		Chain:              q.Get("chain"),
+		ToToken:            q.Get("toToken"),
+		DestinationAddress: q.Get("destinationAddress"),
+		SourceOwner:        q.Get("sourceOwner"),
+		TokenId:            q.Get("tokenId"),
+		BlobHash:           q.Get("blobHash"),
+		TargetChainId:      q.Get("targetChainId"),
+		TargetOwner:        q.Get("targetOwner"),
+		NftId:              q.Get("nftId"),
+	})
+	if err != nil {
+		var bre *badRequestError
+		status := http.StatusInternalServerError
+		if errors.As(err, &bre) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
 	}
-	return token, nil
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
 }
 
 func handleBlobHash(w http.ResponseWriter, r *http.Request) {
@@ -359,6 +414,7 @@ func handleListNFT(w http.ResponseWriter, r *http.Request) {
 		ID          int    `json:"id"`
 		Token       string `json:"token"`
 		BlobHash    string `json:"blobHash"`
+		NftId       string `json:"nftId"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
@@ -366,6 +422,13 @@ func handleListNFT(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// nftId must be a canonical "{class_id}/{id}" identifier (Cosmos SDK
+	// ADR-043 style) before it reaches solverClient.
+	if _, _, err := nftid.ParseCanonical(requestBody.NftId); err != nil {
+		http.Error(w, "Invalid nftId: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Convert int array to byte array
 	// imageBytes := make([]byte, len(requestBody.ImageBytes))
 	// for i, b := range requestBody.ImageBytes {
@@ -384,6 +447,7 @@ func handleListNFT(w http.ResponseWriter, r *http.Request) {
 		ID:          requestBody.ID,
 		Token:       requestBody.Token,
 		BlobHash:    requestBody.BlobHash,
+		NftId:       requestBody.NftId,
 	}
 
 	// List NFT and get blob hash
@@ -393,6 +457,17 @@ func handleListNFT(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	solverClient.PublishEvent("nft.listed", map[string]interface{}{
+		"nftId":    requestBody.NftId,
+		"name":     requestBody.Name,
+		"price":    requestBody.Price,
+		"blobHash": blobHash,
+	}, map[string]string{
+		"chainId": requestBody.ChainId,
+		"owner":   requestBody.ChainOwner,
+		"nftId":   requestBody.NftId,
+	})
+
 	// Return success response with blob hash
 	response := map[string]interface{}{
 		"status":   "success",
@@ -426,6 +501,17 @@ func handleListNFTForSale(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// nftId and tokenId must be canonical "{class_id}/{id}" identifiers
+	// (Cosmos SDK ADR-043 style) before they reach solverClient.
+	if _, _, err := nftid.ParseCanonical(requestBody.NftId); err != nil {
+		http.Error(w, "Invalid nftId: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, _, err := nftid.ParseCanonical(requestBody.TokenId); err != nil {
+		http.Error(w, "Invalid tokenId: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Call the ListNftForSale function with all parameters
 	data, err := solverClient.ListNftForSale(
 		requestBody.Owner,
@@ -440,6 +526,17 @@ func handleListNFTForSale(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	solverClient.PublishEvent("nft.listed", map[string]interface{}{
+		"nftId":   requestBody.NftId,
+		"tokenId": requestBody.TokenId,
+		"price":   requestBody.Price,
+		"data":    data,
+	}, map[string]string{
+		"chainId": requestBody.ChainId,
+		"owner":   requestBody.ChainOwner,
+		"nftId":   requestBody.NftId,
+	})
+
 	// Return success response
 	response := map[string]interface{}{
 		"status": "success",
@@ -472,6 +569,104 @@ func handleGetNFTs(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// handleNFTBalance serves GET /nfts/balance/{owner}, returning the count of
+// NFTs owner holds across all classes.
+func handleNFTBalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	owner := strings.TrimPrefix(r.URL.Path, "/nfts/balance/")
+	if owner == "" {
+		http.Error(w, "owner path parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	balance, err := solverClient.GetBalance(owner)
+	if err != nil {
+		http.Error(w, "Error getting NFT balance: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"status": "success",
+		"data": map[string]uint64{
+			"balance": balance,
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// handleNFTsOfOwner serves GET /nfts/owner/{owner}, returning owner's NFTs.
+// The optional "classId" query param narrows to a single class, and
+// "limit"/"offset" page through the result.
+func handleNFTsOfOwner(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	owner := strings.TrimPrefix(r.URL.Path, "/nfts/owner/")
+	if owner == "" {
+		http.Error(w, "owner path parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	classID := r.URL.Query().Get("classId")
+
+	nfts, err := solverClient.GetNFTsOfOwner(owner, classID)
+	if err != nil {
+		http.Error(w, "Error getting NFTs of owner: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	nfts, err = paginateNFTs(nfts, r.URL.Query().Get("limit"), r.URL.Query().Get("offset"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := map[string]interface{}{
+		"status": "success",
+		"data":   nfts,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// paginateNFTs applies the optional limit/offset query params to nfts,
+// parsing empty strings as "no bound".
+func paginateNFTs(nfts []solver.NFT, limitParam, offsetParam string) ([]solver.NFT, error) {
+	offset := 0
+	if offsetParam != "" {
+		parsed, err := strconv.Atoi(offsetParam)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid offset parameter: %s", offsetParam)
+		}
+		offset = parsed
+	}
+	if offset > len(nfts) {
+		offset = len(nfts)
+	}
+	nfts = nfts[offset:]
+
+	if limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid limit parameter: %s", limitParam)
+		}
+		if limit < len(nfts) {
+			nfts = nfts[:limit]
+		}
+	}
+
+	return nfts, nil
+}
+
 // Add the handler function
 func handleNextNFTID(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
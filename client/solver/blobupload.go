@@ -0,0 +1,135 @@
+package solver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/linera-protocol/examples/universal-solver/client/solver/graphql"
+)
+
+// blobChunkSize is the maximum size of one chunk in a chunked blob upload.
+// PublishDataBlob used to serialize the whole image as a single int-array
+// inside one GraphQL mutation string, which doesn't scale to real NFT art
+// and can't be resumed after a network drop.
+const blobChunkSize = 256 * 1024
+
+// blobUpload tracks which chunks of an in-flight chunked upload, keyed by
+// its Merkle root, have already been acknowledged by the server, so a retry
+// only resends what's missing.
+type blobUpload struct {
+	mu       sync.Mutex
+	total    int
+	uploaded map[int]bool
+}
+
+// inFlightBlobUploads holds one *blobUpload per Merkle root currently being
+// uploaded, so a retry after a network drop resumes the same upload instead
+// of starting over.
+var inFlightBlobUploads sync.Map
+
+// chunkBytes splits data into blobChunkSize chunks, the last one possibly
+// shorter.
+func chunkBytes(data []byte) [][]byte {
+	if len(data) == 0 {
+		return [][]byte{{}}
+	}
+	chunks := make([][]byte, 0, (len(data)+blobChunkSize-1)/blobChunkSize)
+	for start := 0; start < len(data); start += blobChunkSize {
+		end := start + blobChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunks = append(chunks, data[start:end])
+	}
+	return chunks
+}
+
+// merkleRoot computes a SHA-256 Merkle root over chunks, duplicating the
+// last node at each level when the level has an odd number of nodes.
+func merkleRoot(chunks [][]byte) [32]byte {
+	level := make([][32]byte, len(chunks))
+	for i, chunk := range chunks {
+		level[i] = sha256.Sum256(chunk)
+	}
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, sha256.Sum256(append(level[i][:], level[i][:]...)))
+				continue
+			}
+			next = append(next, sha256.Sum256(append(level[i][:], level[i+1][:]...)))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// uploadBlobChunked splits imageBytes into fixed-size chunks, uploads
+// whichever of them haven't already been acknowledged for this root (so a
+// caller can retry after a network drop without resending everything), and
+// finalizes the upload once every chunk lands.
+func (c *Client) uploadBlobChunked(chainId string, imageBytes []byte) (string, error) {
+	chunks := chunkBytes(imageBytes)
+	root := merkleRoot(chunks)
+	rootHex := hex.EncodeToString(root[:])
+
+	uploadIface, _ := inFlightBlobUploads.LoadOrStore(rootHex, &blobUpload{
+		total:    len(chunks),
+		uploaded: make(map[int]bool),
+	})
+	upload := uploadIface.(*blobUpload)
+
+	for index, chunk := range chunks {
+		upload.mu.Lock()
+		alreadyUploaded := upload.uploaded[index]
+		upload.mu.Unlock()
+		if alreadyUploaded {
+			continue
+		}
+
+		byteInts := make([]int, len(chunk))
+		for i, b := range chunk {
+			byteInts[i] = int(b)
+		}
+
+		if err := c.lineraGQL.PublishDataBlobChunk(graphql.PublishDataBlobChunkVariables{
+			ChainId: chainId,
+			Root:    rootHex,
+			Index:   index,
+			Bytes:   byteInts,
+		}); err != nil {
+			return "", fmt.Errorf("error publishing blob chunk %d/%d: %w", index+1, len(chunks), err)
+		}
+
+		upload.mu.Lock()
+		upload.uploaded[index] = true
+		uploaded := len(upload.uploaded)
+		upload.mu.Unlock()
+
+		c.broadcast <- WSMessage{
+			Type: "blob_upload_progress",
+			Data: map[string]interface{}{
+				"root":     rootHex,
+				"uploaded": uploaded,
+				"total":    len(chunks),
+			},
+		}
+	}
+
+	blobHash, err := c.lineraGQL.FinalizeDataBlob(graphql.FinalizeDataBlobVariables{
+		Root:        rootHex,
+		TotalChunks: len(chunks),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error finalizing data blob: %w", err)
+	}
+
+	// Only now, with every chunk acknowledged and the blob finalized, is it
+	// safe to drop the resumability state: a retry after this point is a
+	// fresh upload, not a resume.
+	inFlightBlobUploads.Delete(rootHex)
+	return blobHash, nil
+}
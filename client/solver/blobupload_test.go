@@ -0,0 +1,112 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/linera-protocol/examples/universal-solver/client/solver/graphql"
+)
+
+func TestChunkBytesSplitsIntoFixedSizeChunks(t *testing.T) {
+	data := make([]byte, blobChunkSize+1)
+	chunks := chunkBytes(data)
+
+	if len(chunks) != 2 {
+		t.Fatalf("chunkBytes() returned %d chunks, want 2", len(chunks))
+	}
+	if len(chunks[0]) != blobChunkSize {
+		t.Errorf("chunks[0] has %d bytes, want %d", len(chunks[0]), blobChunkSize)
+	}
+	if len(chunks[1]) != 1 {
+		t.Errorf("chunks[1] has %d bytes, want 1", len(chunks[1]))
+	}
+}
+
+func TestMerkleRootIsDeterministicAndOrderSensitive(t *testing.T) {
+	chunks := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+
+	root1 := merkleRoot(chunks)
+	root2 := merkleRoot([][]byte{[]byte("a"), []byte("b"), []byte("c")})
+	if root1 != root2 {
+		t.Error("merkleRoot() is not deterministic for the same chunks")
+	}
+
+	reordered := merkleRoot([][]byte{[]byte("c"), []byte("b"), []byte("a")})
+	if root1 == reordered {
+		t.Error("merkleRoot() should depend on chunk order")
+	}
+}
+
+func TestMerkleRootChangesWithContent(t *testing.T) {
+	root1 := merkleRoot([][]byte{[]byte("hello")})
+	root2 := merkleRoot([][]byte{[]byte("world")})
+	if bytes.Equal(root1[:], root2[:]) {
+		t.Error("merkleRoot() should differ for different content")
+	}
+}
+
+// TestUploadBlobChunkedResumesOnlyMissingChunksAfterFailure exercises the
+// scenario uploadBlobChunked exists for: chunk 1 fails once (simulating a
+// network drop), the caller retries, and the retry must only resend the
+// chunk that never landed, not start over from chunk 0.
+func TestUploadBlobChunkedResumesOnlyMissingChunksAfterFailure(t *testing.T) {
+	var uploadedIndexes []int
+	chunk1Attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []graphql.Request
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		req := reqs[0]
+
+		if req.Query == "" {
+			t.Fatalf("empty query")
+		}
+
+		switch {
+		case bytes.Contains([]byte(req.Query), []byte("publishDataBlobChunk")):
+			index := int(req.Variables["index"].(float64))
+			if index == 1 {
+				chunk1Attempts++
+				if chunk1Attempts == 1 {
+					json.NewEncoder(w).Encode([]graphql.Response{{Errors: []graphql.Error{{Message: "simulated network drop"}}}})
+					return
+				}
+			}
+			uploadedIndexes = append(uploadedIndexes, index)
+			data, _ := json.Marshal(true)
+			json.NewEncoder(w).Encode([]graphql.Response{{Data: data}})
+		case bytes.Contains([]byte(req.Query), []byte("finalizeDataBlob")):
+			data, _ := json.Marshal("0xblobhash")
+			json.NewEncoder(w).Encode([]graphql.Response{{Data: data}})
+		default:
+			t.Fatalf("unexpected query: %s", req.Query)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("", "", server.URL)
+	imageBytes := make([]byte, 2*blobChunkSize+10)
+
+	if _, err := client.uploadBlobChunked("chain1", imageBytes); err == nil {
+		t.Fatal("expected the first upload attempt to fail on chunk 1")
+	}
+	if uploadedIndexes[len(uploadedIndexes)-1] != 0 {
+		t.Fatalf("uploadedIndexes after failed attempt = %v, want chunk 0 to have landed before the failure", uploadedIndexes)
+	}
+
+	blobHash, err := client.uploadBlobChunked("chain1", imageBytes)
+	if err != nil {
+		t.Fatalf("retry after a transient failure should succeed, got error: %v", err)
+	}
+	if blobHash != "0xblobhash" {
+		t.Errorf("uploadBlobChunked() = %q, want %q", blobHash, "0xblobhash")
+	}
+	if uploadedIndexes[0] != 0 || len(uploadedIndexes) != 3 {
+		t.Fatalf("uploadedIndexes = %v, want [0 <retry of 1> 2] with chunk 0 only sent once across both attempts", uploadedIndexes)
+	}
+}
@@ -0,0 +1,273 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RouteKind identifies the strategy RouteSwap chose for a cross-chain swap.
+type RouteKind string
+
+const (
+	RouteDirectPool     RouteKind = "direct_pool"
+	RouteBridgeThenPool RouteKind = "bridge_then_pool"
+	RoutePoolThenBridge RouteKind = "pool_then_bridge"
+)
+
+// RouteLeg is one hop of a Route: either a solver pool swap (FromToken !=
+// ToToken), which settles on Chain, or a bridge transfer (FromToken ==
+// ToToken) from FromChain to Chain. FromChain is only meaningful for a
+// bridge leg; a pool leg leaves it zero.
+type RouteLeg struct {
+	Chain     string
+	FromChain string
+	FromToken string
+	ToToken   string
+	Amount    float64
+}
+
+// Route is a priced path from one token to another, chosen by RouteSwap as
+// the one with the highest expected output net of fees.
+type Route struct {
+	Kind           RouteKind
+	Legs           []RouteLeg
+	ExpectedOutput float64
+}
+
+// BridgeParams describes one bridge transfer leg.
+type BridgeParams struct {
+	FromChain   string
+	ToChain     string
+	FromAddress string
+	ToAddress   string
+	Amount      float64
+}
+
+// Bridge abstracts a cross-chain message/asset bridge, modeled on the
+// Hop-style L2<->L1 bridge pattern: Quote estimates the output net of
+// bridge fees, Execute actually moves the asset using the given signer.
+type Bridge interface {
+	Name() string
+	SupportsRoute(fromChain, toChain string) bool
+	Quote(ctx context.Context, from, to string, amount float64) (float64, error)
+	Execute(ctx context.Context, signer Signer, params BridgeParams) (string, error)
+}
+
+// BridgeRegistry looks up a Bridge capable of a given chain pair, mirroring
+// the ChainBackend Registry pattern so adding a bridge doesn't require
+// editing RouteSwap.
+type BridgeRegistry struct {
+	mu      sync.RWMutex
+	bridges []Bridge
+}
+
+func NewBridgeRegistry() *BridgeRegistry {
+	return &BridgeRegistry{}
+}
+
+func (r *BridgeRegistry) Register(b Bridge) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bridges = append(r.bridges, b)
+}
+
+// Get returns the first registered bridge that supports fromChain->toChain.
+func (r *BridgeRegistry) Get(fromChain, toChain string) (Bridge, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, b := range r.bridges {
+		if b.SupportsRoute(fromChain, toChain) {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// DefaultBridgeRegistry holds the bridges wired up at init() time.
+var DefaultBridgeRegistry = NewBridgeRegistry()
+
+func init() {
+	DefaultBridgeRegistry.Register(&CanonicalL2Bridge{FromChain: "ethereum", ToChain: "arbitrum", FeeRate: 0.001})
+	DefaultBridgeRegistry.Register(&MessageBridge{FromChain: "ethereum", ToChain: "arbitrum"})
+}
+
+// CanonicalL2Bridge models an L2's canonical bridge contract (e.g.
+// Arbitrum's or Optimism's native deposit/withdraw bridge): fast deposits
+// to the L2, fee-only pricing, executed via the configured ChainBackend.
+type CanonicalL2Bridge struct {
+	FromChain       string
+	ToChain         string
+	ContractAddress string
+	FeeRate         float64
+}
+
+func (b *CanonicalL2Bridge) Name() string { return "canonical-" + b.FromChain + "-" + b.ToChain }
+
+func (b *CanonicalL2Bridge) SupportsRoute(fromChain, toChain string) bool {
+	return fromChain == b.FromChain && toChain == b.ToChain
+}
+
+func (b *CanonicalL2Bridge) Quote(ctx context.Context, from, to string, amount float64) (float64, error) {
+	return amount * (1 - b.FeeRate), nil
+}
+
+func (b *CanonicalL2Bridge) Execute(ctx context.Context, signer Signer, params BridgeParams) (string, error) {
+	backend, ok := DefaultRegistry.Get(params.FromChain)
+	if !ok {
+		return "", fmt.Errorf("no chain backend registered for %s", params.FromChain)
+	}
+	prep, err := backend.PrepareTransaction(ctx, params.FromAddress, b.ContractAddress, weiFromDecimalString(fmt.Sprintf("%v", params.Amount)), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare bridge deposit: %w", err)
+	}
+	return backend.BroadcastSigned(ctx, prep.RawTx)
+}
+
+// MessageBridge models a generic arbitrary-message bridge with separate
+// sendToL2/sendToL1 entry points (e.g. an L1CrossDomainMessenger-style
+// contract), as opposed to CanonicalL2Bridge's asset-only deposit path.
+type MessageBridge struct {
+	FromChain string
+	ToChain   string
+}
+
+func (b *MessageBridge) Name() string { return "message-" + b.FromChain + "-" + b.ToChain }
+
+func (b *MessageBridge) SupportsRoute(fromChain, toChain string) bool {
+	return (fromChain == b.FromChain && toChain == b.ToChain) ||
+		(fromChain == b.ToChain && toChain == b.FromChain)
+}
+
+func (b *MessageBridge) Quote(ctx context.Context, from, to string, amount float64) (float64, error) {
+	// Message bridges relay a deposit/withdrawal proof rather than holding
+	// liquidity themselves, so there's no pool slippage, only a flat
+	// relay fee.
+	const relayFee = 0.0005
+	return amount * (1 - relayFee), nil
+}
+
+func (b *MessageBridge) Execute(ctx context.Context, signer Signer, params BridgeParams) (string, error) {
+	backend, ok := DefaultRegistry.Get(params.FromChain)
+	if !ok {
+		return "", fmt.Errorf("no chain backend registered for %s", params.FromChain)
+	}
+	if params.FromChain == b.FromChain {
+		return b.sendToL2(ctx, backend, params)
+	}
+	return b.sendToL1(ctx, backend, params)
+}
+
+func (b *MessageBridge) sendToL2(ctx context.Context, backend ChainBackend, params BridgeParams) (string, error) {
+	prep, err := backend.PrepareTransaction(ctx, params.FromAddress, params.ToAddress, weiFromDecimalString(fmt.Sprintf("%v", params.Amount)), map[string]any{"direction": "l1_to_l2"})
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare L1->L2 message: %w", err)
+	}
+	return backend.BroadcastSigned(ctx, prep.RawTx)
+}
+
+func (b *MessageBridge) sendToL1(ctx context.Context, backend ChainBackend, params BridgeParams) (string, error) {
+	prep, err := backend.PrepareTransaction(ctx, params.FromAddress, params.ToAddress, weiFromDecimalString(fmt.Sprintf("%v", params.Amount)), map[string]any{"direction": "l2_to_l1"})
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare L2->L1 message: %w", err)
+	}
+	return backend.BroadcastSigned(ctx, prep.RawTx)
+}
+
+// RouteSwap evaluates the direct solver-pool swap alongside any
+// bridge-then-pool / pool-then-bridge routes available for fromToken's and
+// toToken's chains, returning whichever yields the highest expected output
+// net of fees.
+func (c *Client) RouteSwap(fromToken, toToken string, amount float64) (*Route, error) {
+	var best *Route
+
+	if direct, err := c.quoteDirectPoolRoute(fromToken, toToken, amount); err == nil {
+		best = direct
+	}
+
+	for _, kind := range []RouteKind{RouteBridgeThenPool, RoutePoolThenBridge} {
+		route, err := c.quoteBridgeRoute(kind, fromToken, toToken, amount)
+		if err != nil {
+			continue
+		}
+		if best == nil || route.ExpectedOutput > best.ExpectedOutput {
+			best = route
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no viable route from %s to %s", fromToken, toToken)
+	}
+	return best, nil
+}
+
+func (c *Client) quoteDirectPoolRoute(fromToken, toToken string, amount float64) (*Route, error) {
+	result, err := c.CalculateSwap(fromToken, toToken, amount)
+	if err != nil {
+		return nil, err
+	}
+	return &Route{
+		Kind:           RouteDirectPool,
+		Legs:           []RouteLeg{{Chain: c.determineChain(toToken), FromToken: fromToken, ToToken: toToken, Amount: amount}},
+		ExpectedOutput: result.ToAmount,
+	}, nil
+}
+
+func (c *Client) quoteBridgeRoute(kind RouteKind, fromToken, toToken string, amount float64) (*Route, error) {
+	fromChain := c.determineChain(fromToken)
+	toChain := c.determineChain(toToken)
+	if fromChain == toChain {
+		return nil, fmt.Errorf("no bridge needed within chain %s", fromChain)
+	}
+
+	bridge, ok := DefaultBridgeRegistry.Get(fromChain, toChain)
+	if !ok {
+		return nil, fmt.Errorf("no bridge registered from %s to %s", fromChain, toChain)
+	}
+
+	switch kind {
+	case RouteBridgeThenPool:
+		bridged, err := bridge.Quote(context.Background(), fromToken, toToken, amount)
+		if err != nil {
+			return nil, err
+		}
+		poolResult, err := c.CalculateSwap(fromToken, toToken, bridged)
+		if err != nil {
+			return nil, err
+		}
+		return &Route{
+			Kind: kind,
+			Legs: []RouteLeg{
+				// Bridge fromToken over to toChain first, then pool-swap it
+				// into toToken once it's there.
+				{Chain: toChain, FromChain: fromChain, FromToken: fromToken, ToToken: fromToken, Amount: amount},
+				{Chain: toChain, FromToken: fromToken, ToToken: toToken, Amount: bridged},
+			},
+			ExpectedOutput: poolResult.ToAmount,
+		}, nil
+	case RoutePoolThenBridge:
+		poolResult, err := c.CalculateSwap(fromToken, toToken, amount)
+		if err != nil {
+			return nil, err
+		}
+		bridged, err := bridge.Quote(context.Background(), fromToken, toToken, poolResult.ToAmount)
+		if err != nil {
+			return nil, err
+		}
+		return &Route{
+			Kind: kind,
+			Legs: []RouteLeg{
+				// Pool-swap into toToken on fromChain first, then bridge the
+				// result over to toChain. Chain here is the leg's own
+				// settlement chain (fromChain), not determineChain(toToken):
+				// executeSwapLeg settles this leg where the swap actually
+				// runs, before anything has moved to toChain.
+				{Chain: fromChain, FromToken: fromToken, ToToken: toToken, Amount: amount},
+				{Chain: toChain, FromChain: fromChain, FromToken: toToken, ToToken: toToken, Amount: poolResult.ToAmount},
+			},
+			ExpectedOutput: bridged,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported route kind: %s", kind)
+	}
+}
@@ -0,0 +1,95 @@
+package solver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/linera-protocol/examples/universal-solver/client/solver/graphql"
+)
+
+// newCalculateSwapStubServer returns a test server that answers every
+// batched calculateSwap operation by echoing fromToken/toToken and scaling
+// amount by rate, mirroring the shape CalculateSwap expects back.
+func newCalculateSwapStubServer(t *testing.T, rate float64) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []graphql.Request
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("failed to decode batch request: %v", err)
+		}
+
+		resp := make([]graphql.Response, len(reqs))
+		for i, req := range reqs {
+			amount, _ := req.Variables["amount"].(float64)
+			data, _ := json.Marshal(map[string]any{
+				"calculateSwap": map[string]any{
+					"fromToken":    req.Variables["fromToken"],
+					"toToken":      req.Variables["toToken"],
+					"fromAmount":   amount,
+					"toAmount":     amount * rate,
+					"exchangeRate": rate,
+				},
+			})
+			resp[i] = graphql.Response{Data: data}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+// TestQuoteBridgeRouteLegWiring guards against the bridge leg's FromChain/
+// Chain pair collapsing to the same chain (which made
+// DefaultBridgeRegistry.Get always look up a same-chain bridge) or being
+// swapped (which bridged the wrong direction), for both route kinds.
+func TestQuoteBridgeRouteLegWiring(t *testing.T) {
+	server := newCalculateSwapStubServer(t, 0.99)
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "")
+
+	for _, kind := range []RouteKind{RouteBridgeThenPool, RoutePoolThenBridge} {
+		route, err := client.quoteBridgeRoute(kind, "ETH", "ARB", 1.0)
+		if err != nil {
+			t.Fatalf("quoteBridgeRoute(%s) error = %v", kind, err)
+		}
+
+		var bridgeLeg *RouteLeg
+		for i := range route.Legs {
+			if route.Legs[i].FromToken == route.Legs[i].ToToken {
+				bridgeLeg = &route.Legs[i]
+			}
+		}
+		if bridgeLeg == nil {
+			t.Fatalf("quoteBridgeRoute(%s) produced no bridge leg: %+v", kind, route.Legs)
+		}
+
+		// executeBridgeLeg looks up the bridge from leg.FromChain to
+		// leg.Chain directly (no re-derivation), so that's exactly what
+		// must be registered and must run ethereum->arbitrum either way.
+		if _, ok := DefaultBridgeRegistry.Get(bridgeLeg.FromChain, bridgeLeg.Chain); !ok {
+			t.Errorf("quoteBridgeRoute(%s) bridge leg wired %s->%s, no bridge registered for that pair", kind, bridgeLeg.FromChain, bridgeLeg.Chain)
+		}
+		if bridgeLeg.FromChain != "ethereum" || bridgeLeg.Chain != "arbitrum" {
+			t.Errorf("quoteBridgeRoute(%s) bridge leg wired %s->%s, want ethereum->arbitrum", kind, bridgeLeg.FromChain, bridgeLeg.Chain)
+		}
+	}
+}
+
+// TestRouteSwapReachesArbitrumBridge exercises the path end-to-end through
+// RouteSwap: before ARB was registered in knownTokens, determineChain could
+// never return "arbitrum" and this whole routing branch was dead code.
+func TestRouteSwapReachesArbitrumBridge(t *testing.T) {
+	server := newCalculateSwapStubServer(t, 0.99)
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "")
+
+	route, err := client.RouteSwap("ETH", "ARB", 1.0)
+	if err != nil {
+		t.Fatalf("RouteSwap(ETH, ARB) error = %v", err)
+	}
+	if route == nil {
+		t.Fatal("RouteSwap(ETH, ARB) returned a nil route")
+	}
+}
@@ -0,0 +1,395 @@
+package solver
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gagliardetto/solana-go"
+)
+
+// Tx is the chain-specific transaction payload a ChainAdapter hands back
+// from GetTransaction and reads back in ExtractAmount: a
+// map[string]interface{} in both the Ethereum-style JSON-RPC shape and the
+// Solana getTransaction response shape.
+type Tx = interface{}
+
+// ChainAdapter abstracts the chain-specific behavior behind
+// /post_tx_hash — fetching a transaction, extracting the amount/token it
+// moved, and validating an address — so adding a chain is a single
+// RegisterAdapter call instead of edits scattered across handlePostTxHash,
+// extractAmountFromTx, and chainToToken.
+type ChainAdapter interface {
+	Name() string
+	NativeToken() string
+	GetTransaction(rpc, hash string) (Tx, error)
+	ExtractAmount(tx Tx) (amount float64, token string, err error)
+	ValidateAddress(addr string) error
+}
+
+var (
+	adapterRegistryMu sync.RWMutex
+	adapterRegistry   = map[string]ChainAdapter{}
+)
+
+// RegisterAdapter makes a ChainAdapter available by name to GetAdapter and
+// AdapterNames. Call it from an init() func; registering the same
+// Name() twice replaces the earlier adapter.
+func RegisterAdapter(a ChainAdapter) {
+	adapterRegistryMu.Lock()
+	defer adapterRegistryMu.Unlock()
+	adapterRegistry[a.Name()] = a
+}
+
+// GetAdapter looks up a ChainAdapter by name.
+func GetAdapter(name string) (ChainAdapter, bool) {
+	adapterRegistryMu.RLock()
+	defer adapterRegistryMu.RUnlock()
+	a, ok := adapterRegistry[name]
+	return a, ok
+}
+
+// AdapterNames lists every registered adapter name, so a caller can
+// register a "--<chain>-url" flag per chain without knowing the chain set
+// in advance.
+func AdapterNames() []string {
+	adapterRegistryMu.RLock()
+	defer adapterRegistryMu.RUnlock()
+	names := make([]string, 0, len(adapterRegistry))
+	for name := range adapterRegistry {
+		names = append(names, name)
+	}
+	return names
+}
+
+func init() {
+	RegisterAdapter(&EVMAdapter{chainName: "ethereum", nativeSymbol: "ETH", nativeDecimals: 18})
+	RegisterAdapter(&SolanaAdapter{})
+	// EVM-compatible chains: same eth_getTransactionByHash shape and the
+	// same ERC-20 ABI, just a different native symbol and deployment.
+	RegisterAdapter(&EVMAdapter{chainName: "polygon", nativeSymbol: "MATIC", nativeDecimals: 18})
+	RegisterAdapter(&EVMAdapter{chainName: "bsc", nativeSymbol: "BNB", nativeDecimals: 18})
+	RegisterAdapter(&EVMAdapter{chainName: "arbitrum", nativeSymbol: "ETH", nativeDecimals: 18})
+	// Energi uses the same ERC-20 ABI as every other EVM chain here (the
+	// one Blockbook's Energi backend also calls into), just its own chain
+	// ID and native symbol.
+	RegisterAdapter(&EVMAdapter{chainName: "energi", nativeSymbol: "NRG", nativeDecimals: 18})
+}
+
+// EVMAdapter implements ChainAdapter for Ethereum and any EVM-compatible
+// chain reachable over the same eth_getTransactionByHash JSON-RPC and
+// ERC-20 ABI (Polygon, BSC, Arbitrum, Energi, ...); only the native token
+// symbol/decimals differ between chains.
+type EVMAdapter struct {
+	chainName      string
+	nativeSymbol   string
+	nativeDecimals uint8
+}
+
+func (a *EVMAdapter) Name() string        { return a.chainName }
+func (a *EVMAdapter) NativeToken() string { return a.nativeSymbol }
+
+func (a *EVMAdapter) ValidateAddress(addr string) error {
+	if !common.IsHexAddress(addr) {
+		return fmt.Errorf("invalid %s address: %s", a.chainName, addr)
+	}
+	return nil
+}
+
+// GetTransaction fetches tx by hash from the EVM-compatible node at rpc and
+// stashes rpc in the returned map so ExtractAmount can resolve an ERC-20
+// token's decimals()/symbol() from the same node without being passed rpc
+// again.
+func (a *EVMAdapter) GetTransaction(rpc, hash string) (Tx, error) {
+	client, err := ethclient.Dial(rpc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s node: %w", a.chainName, err)
+	}
+	defer client.Close()
+
+	txHash := common.HexToHash(hash)
+	tx, isPending, err := client.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s transaction: %w", a.chainName, err)
+	}
+
+	result := map[string]interface{}{
+		"hash":      tx.Hash().Hex(),
+		"value":     tx.Value().String(),
+		"gas":       tx.Gas(),
+		"gasPrice":  tx.GasPrice().String(),
+		"nonce":     tx.Nonce(),
+		"isPending": isPending,
+		"input":     hexutil.Encode(tx.Data()),
+		"rpc":       rpc,
+	}
+	if to := tx.To(); to != nil {
+		result["to"] = to.Hex()
+	}
+
+	// Recover the sender so a caller confirming an ERC-20 payment (see
+	// ConfirmERC20Payment) can key its watch on who actually sent it,
+	// instead of only the amount.
+	if from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+		result["from"] = from.Hex()
+	}
+
+	return result, nil
+}
+
+// ExtractAmount decodes a standard ERC-20 transfer/transferFrom call from
+// tx's input data, falling back to the native value field when input
+// doesn't match either selector.
+func (a *EVMAdapter) ExtractAmount(tx Tx) (float64, string, error) {
+	v, ok := tx.(map[string]interface{})
+	if !ok {
+		return 0, "", fmt.Errorf("could not extract amount from transaction")
+	}
+
+	if inputHex, ok := v["input"].(string); ok {
+		if amt, symbol, found, err := a.extractERC20Transfer(v, inputHex); found {
+			return amt, symbol, err
+		}
+	}
+
+	value, ok := v["value"].(string)
+	if !ok {
+		return 0, "", fmt.Errorf("could not extract amount from transaction")
+	}
+
+	bigValue := new(big.Int)
+	if _, success := bigValue.SetString(value, 10); !success {
+		return 0, "", fmt.Errorf("failed to parse decimal value: %s", value)
+	}
+	weiPerUnit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.nativeDecimals)), nil)
+	nativeValue := new(big.Int).Div(bigValue, weiPerUnit)
+	if !nativeValue.IsUint64() {
+		return 0, "", fmt.Errorf("converted %s value exceeds uint64 range: %s", a.nativeSymbol, nativeValue.String())
+	}
+	flval, _ := nativeValue.Float64()
+	return flval, a.nativeSymbol, nil
+}
+
+// extractERC20Transfer decodes a standard ERC-20 transfer/transferFrom
+// call from an EVM transaction's input data, resolving the token's
+// decimals/symbol from its contract over the rpc endpoint GetTransaction
+// stashed in tx. found is false when input doesn't match either selector,
+// in which case the caller should fall back to the native value field.
+func (a *EVMAdapter) extractERC20Transfer(tx map[string]interface{}, inputHex string) (amount float64, symbol string, found bool, err error) {
+	inputBytes, decErr := hex.DecodeString(strings.TrimPrefix(inputHex, "0x"))
+	if decErr != nil {
+		return 0, "", false, nil
+	}
+
+	rawAmount, ok := DecodeERC20Transfer(inputBytes)
+	if !ok {
+		return 0, "", false, nil
+	}
+
+	tokenAddr, ok := tx["to"].(string)
+	if !ok || tokenAddr == "" {
+		return 0, "", true, fmt.Errorf("erc-20 transfer call missing token contract address")
+	}
+	rpc, _ := tx["rpc"].(string)
+	address := common.HexToAddress(tokenAddr)
+
+	decimals, err := erc20Decimals(rpc, address)
+	if err != nil {
+		return 0, "", true, fmt.Errorf("error fetching token decimals: %w", err)
+	}
+
+	// Symbol is cosmetic; fall back to the contract address rather than
+	// failing the whole swap over it.
+	tokenSymbol, symbolErr := erc20Symbol(rpc, address)
+	if symbolErr != nil {
+		tokenSymbol = tokenAddr
+	}
+
+	return rawToFloat(rawAmount, decimals), tokenSymbol, true, nil
+}
+
+// SolanaAdapter implements ChainAdapter for Solana, preferring an SPL
+// token balance delta over the native lamports delta when a transaction
+// carries one.
+type SolanaAdapter struct{}
+
+func (a *SolanaAdapter) Name() string        { return "solana" }
+func (a *SolanaAdapter) NativeToken() string { return "SOL" }
+
+func (a *SolanaAdapter) ValidateAddress(addr string) error {
+	if _, err := solana.PublicKeyFromBase58(addr); err != nil {
+		return fmt.Errorf("invalid Solana address: %w", err)
+	}
+	return nil
+}
+
+// GetTransaction fetches tx by hash from the Solana RPC cluster at rpc,
+// retrying while the node hasn't indexed it yet.
+func (a *SolanaAdapter) GetTransaction(rpc, hash string) (Tx, error) {
+	requestBody := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getTransaction",
+		"params": []interface{}{
+			hash,
+			map[string]interface{}{
+				"encoding":                       "json",
+				"maxSupportedTransactionVersion": 0,
+			},
+		},
+	}
+
+	var response interface{}
+	var err error
+	for i := 0; i < 10; i++ {
+		response, err = doJSONRPCRequest(rpc, requestBody)
+		if responseMap, ok := response.(map[string]interface{}); ok {
+			if responseMap["result"] == nil {
+				time.Sleep(5 * time.Second)
+				continue // Retry if result is nil
+			}
+		}
+
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Solana transaction after 10 retries: %w", err)
+	}
+
+	return response, nil
+}
+
+// ExtractAmount prefers the SPL token balance delta for the fee payer
+// (account index 0) over the native lamports delta when tx's meta carries
+// pre/post token balances.
+func (a *SolanaAdapter) ExtractAmount(tx Tx) (float64, string, error) {
+	v, ok := tx.(map[string]interface{})
+	if !ok {
+		return 0, "", fmt.Errorf("could not extract amount from transaction")
+	}
+
+	result, ok := v["result"].(map[string]interface{})
+	if !ok {
+		return 0, "", fmt.Errorf("could not extract amount from transaction")
+	}
+	meta, ok := result["meta"].(map[string]interface{})
+	if !ok {
+		return 0, "", fmt.Errorf("could not extract amount from transaction")
+	}
+
+	if amt, symbol, found := extractSPLTransfer(meta); found {
+		return amt, symbol, nil
+	}
+
+	preBalances, ok := meta["preBalances"].([]interface{})
+	if !ok || len(preBalances) == 0 {
+		return 0, "", fmt.Errorf("could not extract amount from transaction")
+	}
+	postBalances, ok := meta["postBalances"].([]interface{})
+	if !ok || len(postBalances) == 0 {
+		return 0, "", fmt.Errorf("could not extract amount from transaction")
+	}
+
+	preBalance := uint64(preBalances[0].(float64))
+	postBalance := uint64(postBalances[0].(float64))
+	if preBalance <= postBalance {
+		return 0, "", fmt.Errorf("could not extract amount from transaction")
+	}
+
+	lamports := preBalance - postBalance
+	solValue := float64(lamports) / 1e9
+	if solValue > float64(^uint64(0)) {
+		return 0, "", fmt.Errorf("converted SOL value exceeds uint64 range: %f", solValue)
+	}
+	return solValue, "SOL", nil
+}
+
+// extractSPLTransfer reads the SPL token balance delta for the fee payer
+// (account index 0, same convention used for the native lamports delta
+// above) from a Solana transaction's meta, preferring it over the native
+// balance change when present.
+func extractSPLTransfer(meta map[string]interface{}) (amount float64, symbol string, found bool) {
+	pre, _ := meta["preTokenBalances"].([]interface{})
+	post, _ := meta["postTokenBalances"].([]interface{})
+	if len(pre) == 0 || len(post) == 0 {
+		return 0, "", false
+	}
+
+	preBal, ok := splBalanceAtIndex(pre, 0)
+	if !ok {
+		return 0, "", false
+	}
+	postBal, ok := splBalanceAtIndex(post, 0)
+	if !ok {
+		return 0, "", false
+	}
+
+	preAmount, err := splRawAmount(preBal)
+	if err != nil {
+		return 0, "", false
+	}
+	postAmount, err := splRawAmount(postBal)
+	if err != nil {
+		return 0, "", false
+	}
+	if preAmount.Cmp(postAmount) <= 0 {
+		return 0, "", false
+	}
+
+	decimals := splDecimals(preBal)
+	delta := new(big.Int).Sub(preAmount, postAmount)
+	mint, _ := preBal["mint"].(string)
+
+	return rawToFloat(delta, decimals), mint, true
+}
+
+// splBalanceAtIndex finds the token balance entry for accountIndex among
+// preTokenBalances/postTokenBalances.
+func splBalanceAtIndex(balances []interface{}, accountIndex int) (map[string]interface{}, bool) {
+	for _, b := range balances {
+		entry, ok := b.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		idx, ok := entry["accountIndex"].(float64)
+		if ok && int(idx) == accountIndex {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+func splRawAmount(balance map[string]interface{}) (*big.Int, error) {
+	ui, ok := balance["uiTokenAmount"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing uiTokenAmount")
+	}
+	amountStr, ok := ui["amount"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing uiTokenAmount.amount")
+	}
+	amount, success := new(big.Int).SetString(amountStr, 10)
+	if !success {
+		return nil, fmt.Errorf("invalid uiTokenAmount.amount: %s", amountStr)
+	}
+	return amount, nil
+}
+
+func splDecimals(balance map[string]interface{}) uint8 {
+	ui, ok := balance["uiTokenAmount"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	decimals, _ := ui["decimals"].(float64)
+	return uint8(decimals)
+}
@@ -0,0 +1,87 @@
+package solver
+
+import "testing"
+
+// runAdapterConformanceSuite exercises the parts of ChainAdapter that
+// don't require a live node, so any future adapter added to
+// adapterRegistry can reuse this check rather than each author growing
+// its own.
+func runAdapterConformanceSuite(t *testing.T, a ChainAdapter) {
+	t.Helper()
+
+	if a.Name() == "" {
+		t.Fatal("ChainAdapter.Name() must return a non-empty chain identifier")
+	}
+	if a.NativeToken() == "" {
+		t.Fatalf("ChainAdapter %q: NativeToken() must return a non-empty symbol", a.Name())
+	}
+
+	RegisterAdapter(a)
+	got, ok := GetAdapter(a.Name())
+	if !ok {
+		t.Fatalf("expected adapter %q to be retrievable after RegisterAdapter", a.Name())
+	}
+	if got.Name() != a.Name() {
+		t.Fatalf("GetAdapter returned adapter %q, want %q", got.Name(), a.Name())
+	}
+}
+
+func TestEthereumAdapterConformance(t *testing.T) {
+	runAdapterConformanceSuite(t, &EVMAdapter{chainName: "ethereum", nativeSymbol: "ETH", nativeDecimals: 18})
+}
+
+func TestSolanaAdapterConformance(t *testing.T) {
+	runAdapterConformanceSuite(t, &SolanaAdapter{})
+}
+
+func TestEVMAdapterConformanceForEVMCompatibleChains(t *testing.T) {
+	for _, cfg := range []struct {
+		name   string
+		symbol string
+	}{
+		{"polygon", "MATIC"},
+		{"bsc", "BNB"},
+		{"arbitrum", "ETH"},
+		{"energi", "NRG"},
+	} {
+		t.Run(cfg.name, func(t *testing.T) {
+			runAdapterConformanceSuite(t, &EVMAdapter{chainName: cfg.name, nativeSymbol: cfg.symbol, nativeDecimals: 18})
+		})
+	}
+}
+
+func TestGetAdapterUnregisteredChain(t *testing.T) {
+	if _, ok := GetAdapter("nonexistent-chain"); ok {
+		t.Fatal("expected lookup of an unregistered chain to fail")
+	}
+}
+
+func TestDefaultAdapterRegistryHasBuiltinAdapters(t *testing.T) {
+	for _, name := range []string{"ethereum", "solana", "polygon", "bsc", "arbitrum", "energi"} {
+		if _, ok := GetAdapter(name); !ok {
+			t.Errorf("expected adapter registry to have a %q adapter registered at init", name)
+		}
+	}
+}
+
+func TestEVMAdapterValidateAddress(t *testing.T) {
+	a := &EVMAdapter{chainName: "ethereum", nativeSymbol: "ETH", nativeDecimals: 18}
+
+	if err := a.ValidateAddress("0x0000000000000000000000000000000000000000"); err != nil {
+		t.Errorf("expected a well-formed hex address to validate, got: %v", err)
+	}
+	if err := a.ValidateAddress("not-an-address"); err == nil {
+		t.Error("expected an invalid hex address to fail validation")
+	}
+}
+
+func TestSolanaAdapterValidateAddress(t *testing.T) {
+	a := &SolanaAdapter{}
+
+	if err := a.ValidateAddress("11111111111111111111111111111111"); err != nil {
+		t.Errorf("expected a well-formed base58 address to validate, got: %v", err)
+	}
+	if err := a.ValidateAddress("not-an-address!!"); err == nil {
+		t.Error("expected an invalid base58 address to fail validation")
+	}
+}
@@ -0,0 +1,315 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Confirmation reports the progress of a submitted transaction as observed
+// by a ChainBackend's confirmation subscription.
+type Confirmation struct {
+	TxHash        string
+	Confirmations uint64
+	Status        string // "pending", "confirmed", "failed"
+}
+
+// ChainBackend abstracts the chain-specific behavior that used to be
+// scattered across switch statements keyed on TransactionPrep.Chain, so a
+// new chain can be added by registering an implementation rather than
+// editing every call site that branches on chain name.
+type ChainBackend interface {
+	Name() string
+	PrepareTransaction(ctx context.Context, from, to string, amount *big.Int, extra map[string]any) (*TransactionPrep, error)
+	BroadcastSigned(ctx context.Context, rawSignedTx string) (string, error)
+	GetBalance(ctx context.Context, addr string) (Balance, error)
+	SubscribeConfirmations(ctx context.Context, txHash string) (<-chan Confirmation, error)
+}
+
+// Registry looks up a ChainBackend by chain name at runtime, so the
+// WebSocket handler and swap/mint flows never need to branch on chain
+// identity themselves.
+type Registry struct {
+	mu       sync.RWMutex
+	backends map[string]ChainBackend
+}
+
+func NewRegistry() *Registry {
+	return &Registry{backends: make(map[string]ChainBackend)}
+}
+
+func (r *Registry) Register(b ChainBackend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.backends[b.Name()] = b
+}
+
+func (r *Registry) Get(name string) (ChainBackend, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.backends[name]
+	return b, ok
+}
+
+// DefaultRegistry holds the backends wired up at init() time. Additional
+// chains (a new EVM chain, a Cosmos chain, ...) register themselves here
+// instead of requiring edits to the solver package.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.Register(&EthBackend{})
+	DefaultRegistry.Register(&SolanaBackend{})
+}
+
+// EthBackend implements ChainBackend for Ethereum-compatible chains using
+// EthereumRPC as the node endpoint.
+type EthBackend struct{}
+
+func (b *EthBackend) Name() string { return "ethereum" }
+
+func (b *EthBackend) PrepareTransaction(ctx context.Context, from, to string, amount *big.Int, extra map[string]any) (prep *TransactionPrep, err error) {
+	client, err := ethclient.Dial(EthereumRPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	nonce, err := defaultNonceManager.Reserve(ctx, "ethereum", from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	// If this function returns an error after the nonce is reserved, resync
+	// so the next Reserve re-seeds from the chain instead of leaving a
+	// permanent gap that stalls every later transaction from from.
+	defer func() {
+		if err != nil {
+			defaultNonceManager.Resync("ethereum", from)
+		}
+	}()
+
+	return &TransactionPrep{
+		Chain: "ethereum",
+		ChainParams: ChainParams{
+			FromAddress: from,
+			ToAddress:   to,
+			Amount:      amount.String(),
+			GasPrice:    gasPrice.String(),
+			GasLimit:    21000,
+			Nonce:       nonce,
+		},
+	}, nil
+}
+
+func (b *EthBackend) BroadcastSigned(ctx context.Context, rawSignedTx string) (string, error) {
+	client, err := ethclient.Dial(EthereumRPC)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	rawTxBytes, err := hexutil.Decode(rawSignedTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode raw transaction: %w", err)
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(rawTxBytes); err != nil {
+		return "", fmt.Errorf("failed to unmarshal transaction: %w", err)
+	}
+	if err := client.SendTransaction(ctx, &tx); err != nil {
+		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+	return tx.Hash().Hex(), nil
+}
+
+func (b *EthBackend) GetBalance(ctx context.Context, addr string) (Balance, error) {
+	if !common.IsHexAddress(addr) {
+		return Balance{}, fmt.Errorf("invalid Ethereum address")
+	}
+	client, err := ethclient.Dial(EthereumRPC)
+	if err != nil {
+		return Balance{}, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	balance, err := client.BalanceAt(ctx, common.HexToAddress(addr), nil)
+	if err != nil {
+		return Balance{}, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	ethValue := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(1e18))
+	amount, _ := ethValue.Float64()
+	return Balance{Address: addr, Amount: amount, Symbol: "ETH"}, nil
+}
+
+func (b *EthBackend) SubscribeConfirmations(ctx context.Context, txHash string) (<-chan Confirmation, error) {
+	client, err := ethclient.Dial(EthereumRPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+
+	ch := make(chan Confirmation, 1)
+	go func() {
+		defer client.Close()
+		defer close(ch)
+
+		hash := common.HexToHash(txHash)
+		ticker := time.NewTicker(3 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				receipt, err := client.TransactionReceipt(ctx, hash)
+				if err != nil {
+					ch <- Confirmation{TxHash: txHash, Status: "pending"}
+					continue
+				}
+				latest, err := client.BlockNumber(ctx)
+				if err != nil {
+					continue
+				}
+				confirmations := latest - receipt.BlockNumber.Uint64()
+				status := "confirmed"
+				if receipt.Status == types.ReceiptStatusFailed {
+					status = "failed"
+				}
+				ch <- Confirmation{TxHash: txHash, Confirmations: confirmations, Status: status}
+				if status == "failed" {
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// WatchPayment implements PaymentWatcher for EthBackend by delegating to a
+// LogWatcher over the configured EthereumRPC endpoint.
+func (b *EthBackend) WatchPayment(ctx context.Context, params PaymentWatchParams) (<-chan WSMessage, error) {
+	return NewLogWatcher(EthereumRPC).WatchPayment(ctx, params)
+}
+
+// solanaFinalizedConfirmations is reported for a transaction once Solana's
+// "finalized" commitment level is reached. Solana doesn't expose a
+// meaningful per-slot count at that point (getSignatureStatuses stops
+// tracking it and returns nil), so this stands in as "at least this many",
+// mirroring the ~32-slot supermajority root depth finalization implies.
+const solanaFinalizedConfirmations = 32
+
+// SolanaBackend implements ChainBackend for Solana using SolanaRPC as the
+// cluster endpoint.
+type SolanaBackend struct{}
+
+func (b *SolanaBackend) Name() string { return "solana" }
+
+func (b *SolanaBackend) PrepareTransaction(ctx context.Context, from, to string, amount *big.Int, extra map[string]any) (*TransactionPrep, error) {
+	client := rpc.New(SolanaRPC)
+	resp, err := client.GetLatestBlockhash(ctx, rpc.CommitmentConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent blockhash: %w", err)
+	}
+
+	lamports, _ := new(big.Float).SetInt(amount).Float64()
+	return &TransactionPrep{
+		Chain: "solana",
+		ChainParams: ChainParams{
+			FromAddress:     from,
+			ToAddress:       to,
+			Amount:          amount.String(),
+			RecentBlockhash: resp.Value.Blockhash.String(),
+			Lamports:        lamports,
+		},
+	}, nil
+}
+
+func (b *SolanaBackend) BroadcastSigned(ctx context.Context, rawSignedTx string) (string, error) {
+	client := rpc.New(SolanaRPC)
+	sig, err := client.SendEncodedTransactionWithOpts(ctx, rawSignedTx, rpc.TransactionOpts{Encoding: solana.EncodingBase58})
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+	return sig.String(), nil
+}
+
+func (b *SolanaBackend) GetBalance(ctx context.Context, addr string) (Balance, error) {
+	pubKey, err := solana.PublicKeyFromBase58(addr)
+	if err != nil {
+		return Balance{}, fmt.Errorf("invalid Solana address: %w", err)
+	}
+
+	client := rpc.New(SolanaRPC)
+	balance, err := client.GetBalance(ctx, pubKey, rpc.CommitmentFinalized)
+	if err != nil {
+		return Balance{}, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	solBalance := float64(balance.Value) / float64(solana.LAMPORTS_PER_SOL)
+	return Balance{Address: addr, Amount: solBalance, Symbol: "SOL"}, nil
+}
+
+func (b *SolanaBackend) SubscribeConfirmations(ctx context.Context, txHash string) (<-chan Confirmation, error) {
+	ch := make(chan Confirmation, 1)
+	go func() {
+		defer close(ch)
+
+		client := rpc.New(SolanaRPC)
+		sig, err := solana.SignatureFromBase58(txHash)
+		if err != nil {
+			ch <- Confirmation{TxHash: txHash, Status: "failed"}
+			return
+		}
+
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				statuses, err := client.GetSignatureStatuses(ctx, true, sig)
+				if err != nil || len(statuses.Value) == 0 || statuses.Value[0] == nil {
+					ch <- Confirmation{TxHash: txHash, Status: "pending"}
+					continue
+				}
+				c, done := solanaConfirmationFromStatus(txHash, statuses.Value[0])
+				ch <- c
+				if done {
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// solanaConfirmationFromStatus turns one signature status into a
+// Confirmation, and reports whether the subscription is done (failed or
+// finalized) and should stop polling. getSignatureStatuses stops tracking a
+// per-slot confirmation count once a signature reaches "finalized" -
+// status.Confirmations is nil at that point - so the finalized branch must
+// not dereference it.
+func solanaConfirmationFromStatus(txHash string, status *rpc.SignatureStatusesResult) (Confirmation, bool) {
+	if status.Err != nil {
+		return Confirmation{TxHash: txHash, Status: "failed"}, true
+	}
+	if status.ConfirmationStatus == rpc.ConfirmationStatusFinalized {
+		return Confirmation{TxHash: txHash, Status: "confirmed", Confirmations: solanaFinalizedConfirmations}, true
+	}
+	return Confirmation{TxHash: txHash, Status: "pending"}, false
+}
@@ -0,0 +1,104 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// runBackendConformanceSuite exercises the parts of ChainBackend that don't
+// require a live node, so any future backend added to DefaultRegistry can
+// reuse this check rather than each author growing its own. Backend.Name
+// and registry round-tripping are the only properties every implementation
+// must satisfy without network access.
+func runBackendConformanceSuite(t *testing.T, b ChainBackend) {
+	t.Helper()
+
+	if b.Name() == "" {
+		t.Fatal("ChainBackend.Name() must return a non-empty chain identifier")
+	}
+
+	r := NewRegistry()
+	r.Register(b)
+
+	got, ok := r.Get(b.Name())
+	if !ok {
+		t.Fatalf("expected backend %q to be retrievable after Register", b.Name())
+	}
+	if got.Name() != b.Name() {
+		t.Fatalf("registry returned backend %q, want %q", got.Name(), b.Name())
+	}
+
+	if _, ok := r.Get("nonexistent-chain"); ok {
+		t.Fatal("expected lookup of an unregistered chain to fail")
+	}
+}
+
+func TestEthBackendConformance(t *testing.T) {
+	runBackendConformanceSuite(t, &EthBackend{})
+}
+
+func TestSolanaBackendConformance(t *testing.T) {
+	runBackendConformanceSuite(t, &SolanaBackend{})
+}
+
+func TestSolanaConfirmationFromStatusFinalizedWithNilConfirmations(t *testing.T) {
+	// getSignatureStatuses returns a nil Confirmations exactly when
+	// ConfirmationStatus is Finalized; this must not panic.
+	status := &rpc.SignatureStatusesResult{
+		Confirmations:      nil,
+		ConfirmationStatus: rpc.ConfirmationStatusFinalized,
+	}
+
+	c, done := solanaConfirmationFromStatus("0xabc", status)
+
+	if !done {
+		t.Fatal("expected a finalized status to be done")
+	}
+	if c.Status != "confirmed" {
+		t.Errorf("Status = %q, want %q", c.Status, "confirmed")
+	}
+	if c.Confirmations == 0 {
+		t.Error("expected a non-zero confirmation count for a finalized tx")
+	}
+}
+
+func TestSolanaConfirmationFromStatusPendingBeforeFinalized(t *testing.T) {
+	confirmations := uint64(3)
+	status := &rpc.SignatureStatusesResult{
+		Confirmations:      &confirmations,
+		ConfirmationStatus: rpc.ConfirmationStatusConfirmed,
+	}
+
+	c, done := solanaConfirmationFromStatus("0xabc", status)
+
+	if done {
+		t.Fatal("expected a non-finalized status to not be done")
+	}
+	if c.Status != "pending" {
+		t.Errorf("Status = %q, want %q", c.Status, "pending")
+	}
+}
+
+func TestSolanaConfirmationFromStatusErr(t *testing.T) {
+	status := &rpc.SignatureStatusesResult{
+		Err: map[string]any{"InstructionError": []any{0, "Custom"}},
+	}
+
+	c, done := solanaConfirmationFromStatus("0xabc", status)
+
+	if !done {
+		t.Fatal("expected an errored status to be done")
+	}
+	if c.Status != "failed" {
+		t.Errorf("Status = %q, want %q", c.Status, "failed")
+	}
+}
+
+func TestDefaultRegistryHasBuiltinBackends(t *testing.T) {
+	for _, name := range []string{"ethereum", "solana"} {
+		if _, ok := DefaultRegistry.Get(name); !ok {
+			t.Errorf("expected DefaultRegistry to have a %q backend registered at init", name)
+		}
+	}
+}
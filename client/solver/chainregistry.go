@@ -0,0 +1,108 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// EVMChainConfig is one EVM chain's registration: RPC endpoint, chain id,
+// and the marketplace/NFT contract deployed on it. This mirrors how
+// per-chain coin packages (e.g. Blockbook's Energi package) register their
+// own RPC and contract params instead of assuming a single network.
+type EVMChainConfig struct {
+	Name        string
+	RPCURL      string
+	ChainID     int64
+	NFTContract string
+	ERC20Tokens map[string]string
+}
+
+// evmChainEntry pools the dialed client and cached NFT contract binding for
+// one registered chain, plus the network id resolved once at registration
+// instead of being re-fetched (or hard-coded) on every transaction.
+type evmChainEntry struct {
+	config    EVMChainConfig
+	client    *ethclient.Client
+	nftBound  *bind.BoundContract
+	networkID *big.Int
+}
+
+// EVMChainRegistry holds one pooled *ethclient.Client and cached NFT
+// *bind.BoundContract per registered EVM chain key, so GetEthereumBalance,
+// ExecuteNFTContractTransaction, and ListToken stop hard-coding EthereumRPC
+// / NFTAddress / chain id 1337 and stop re-dialing the node on every call.
+type EVMChainRegistry struct {
+	mu     sync.RWMutex
+	chains map[string]*evmChainEntry
+}
+
+func NewEVMChainRegistry() *EVMChainRegistry {
+	return &EVMChainRegistry{chains: make(map[string]*evmChainEntry)}
+}
+
+// DefaultEVMChainRegistry is populated by InitConfig (and RegisterEVMChain
+// for any additional chain) and used by the package-level Ethereum
+// balance/NFT methods.
+var DefaultEVMChainRegistry = NewEVMChainRegistry()
+
+// Register dials cfg.RPCURL, resolves its network id once, and stores the
+// pooled client and NFT contract binding under chainKey. Registering the
+// same chainKey again replaces the entry, closing the old client.
+func (r *EVMChainRegistry) Register(chainKey string, cfg EVMChainConfig) error {
+	client, err := ethclient.Dial(cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s node: %w", cfg.Name, err)
+	}
+
+	networkID, err := client.NetworkID(context.Background())
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("failed to fetch network id for %s: %w", cfg.Name, err)
+	}
+
+	var nftBound *bind.BoundContract
+	if cfg.NFTContract != "" {
+		nftBound = bind.NewBoundContract(common.HexToAddress(cfg.NFTContract), marketplaceABI, client, client, client)
+	}
+
+	entry := &evmChainEntry{
+		config:    cfg,
+		client:    client,
+		nftBound:  nftBound,
+		networkID: networkID,
+	}
+
+	r.mu.Lock()
+	old := r.chains[chainKey]
+	r.chains[chainKey] = entry
+	r.mu.Unlock()
+
+	if old != nil {
+		old.client.Close()
+	}
+	return nil
+}
+
+// Get returns the entry registered under chainKey.
+func (r *EVMChainRegistry) Get(chainKey string) (*evmChainEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.chains[chainKey]
+	return entry, ok
+}
+
+// Close shuts down every pooled client.
+func (r *EVMChainRegistry) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range r.chains {
+		entry.client.Close()
+	}
+	r.chains = make(map[string]*evmChainEntry)
+}
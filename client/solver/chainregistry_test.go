@@ -0,0 +1,11 @@
+package solver
+
+import "testing"
+
+func TestEVMChainRegistryGetUnregisteredChain(t *testing.T) {
+	r := NewEVMChainRegistry()
+
+	if _, ok := r.Get("nonexistent-chain"); ok {
+		t.Fatal("expected lookup of an unregistered chain to fail")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -23,6 +24,7 @@ import (
 	"github.com/gagliardetto/solana-go/programs/system"
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/gorilla/websocket"
+	"github.com/linera-protocol/examples/universal-solver/client/solver/graphql"
 	"github.com/linera-protocol/examples/universal-solver/client/solver/keys"
 	"github.com/mr-tron/base58"
 )
@@ -54,6 +56,14 @@ func InitConfig(ethereumURL, solanaURL, nftAddress string) {
 	EthereumRPC = ethereumURL
 	SolanaRPC = solanaURL
 	NFTAddress = nftAddress
+
+	if err := DefaultEVMChainRegistry.Register("ethereum", EVMChainConfig{
+		Name:        "ethereum",
+		RPCURL:      ethereumURL,
+		NFTContract: nftAddress,
+	}); err != nil {
+		Logger.Printf("failed to register default ethereum chain: %v", err)
+	}
 }
 
 // InitKeys initializes the private keys from a seed phrase
@@ -78,20 +88,99 @@ type Client struct {
 	nonFungibleURL string
 	lineraURL      string
 	http           *http.Client
+	signer         Signer
+
+	// solverGQL, nonFungibleGQL and lineraGQL are typed graphql.Client
+	// instances for the solver, non-fungible and Linera node endpoints
+	// respectively, used by every swap/NFT mutation and query instead of
+	// fmt.Sprintf-ing values into query text.
+	solverGQL      *graphql.Client
+	nonFungibleGQL *graphql.Client
+	lineraGQL      *graphql.Client
+
+	// paymentChannels holds one open PaymentChannel per seller address, so
+	// repeated buys from the same seller settle with signed off-chain
+	// tickets instead of a separate executeSale transaction each time.
+	paymentChannelsLock sync.RWMutex
+	paymentChannels     map[string]*openPaymentChannel
+
+	// journal records each swap leg through calculated -> prepared ->
+	// signed -> submitted/failed, so NewClient can replay in-flight swaps
+	// after a crash instead of leaving them in an undefined state.
+	journal Journal
+
+	// confirmationWatcher tracks submitted transactions until they reach
+	// a confirmation depth (or reorg/fail), streaming status over
+	// broadcast.
+	confirmationWatcher *ConfirmationWatcher
 
 	// WebSocket related fields
 	upgrader    websocket.Upgrader
 	clients     map[*websocket.Conn]bool
 	clientsLock sync.RWMutex
 	broadcast   chan WSMessage
+
+	// swapSubs holds graphql-ws style subscriptions to a given swap's
+	// (tx hash's) status updates, keyed by swap id.
+	swapSubsLock sync.RWMutex
+	swapSubs     map[string][]swapSubscription
+
+	// events is the in-process pub/sub NFT mutations (ListNFT,
+	// ListNftForSale) and confirmed transfers publish to; WebSocket
+	// clients subscribe to its topics via the "subscribe" message.
+	events *EventBus
+
+	// topicSubs holds the live EventBus Subscription behind each
+	// connection's "subscribe"-to-a-topic operation id, so "complete" and
+	// disconnect can close exactly the right ones.
+	topicSubsLock sync.Mutex
+	topicSubs     map[eventSubKey]*Subscription
+}
+
+// eventSubKey identifies one live topic subscription by connection plus
+// the client-chosen "subscribe" operation id, mirroring how swapSubscription
+// demuxes graphql-ws subscriptions sharing a connection.
+type eventSubKey struct {
+	conn *websocket.Conn
+	id   string
+}
+
+// swapSubscription is one graphql-ws "subscribe" registered against a swap
+// id: id is the operation id the client chose, echoed back on every "next"
+// message so it can demux subscriptions sharing a connection.
+type swapSubscription struct {
+	conn *websocket.Conn
+	id   string
+}
+
+// ClientOption customizes a Client constructed by NewClient.
+type ClientOption func(*Client)
+
+// WithSigner overrides the default LocalSigner, letting operators sign with
+// a KMS-held key (AWSKMSSigner) or a remote signing service (RemoteSigner)
+// instead of keeping private keys in this process.
+func WithSigner(signer Signer) ClientOption {
+	return func(c *Client) { c.signer = signer }
 }
 
-func NewClient(solverURL, nonFungibleURL, lineraURL string) *Client {
+// WithJournal overrides the default on-disk swap journal, e.g. to point it
+// at a different path or swap in a different Journal implementation
+// entirely.
+func WithJournal(journal Journal) ClientOption {
+	return func(c *Client) { c.journal = journal }
+}
+
+func NewClient(solverURL, nonFungibleURL, lineraURL string, opts ...ClientOption) *Client {
 	client := &Client{
-		solverURL:      solverURL,
-		nonFungibleURL: nonFungibleURL,
-		lineraURL:      lineraURL,
-		http:           &http.Client{},
+		solverURL:       solverURL,
+		nonFungibleURL:  nonFungibleURL,
+		lineraURL:       lineraURL,
+		http:            &http.Client{},
+		signer:          LocalSigner{},
+		solverGQL:       graphql.NewClient(solverURL),
+		nonFungibleGQL:  graphql.NewClient(nonFungibleURL),
+		lineraGQL:       graphql.NewClient(lineraURL),
+		paymentChannels: make(map[string]*openPaymentChannel),
 
 		// Initialize WebSocket fields
 		upgrader: websocket.Upgrader{
@@ -101,79 +190,134 @@ func NewClient(solverURL, nonFungibleURL, lineraURL string) *Client {
 		},
 		clients:   make(map[*websocket.Conn]bool),
 		broadcast: make(chan WSMessage),
+		swapSubs:  make(map[string][]swapSubscription),
+		events:    NewEventBus(),
+		topicSubs: make(map[eventSubKey]*Subscription),
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.journal == nil {
+		j, err := NewFileJournal("swap_journal.json")
+		if err != nil {
+			Logger.Printf("failed to open default swap journal, falling back to in-memory (not crash-safe): %v", err)
+			j = nil
+		}
+		if j != nil {
+			client.journal = j
+		} else {
+			client.journal = newMemoryJournal()
+		}
 	}
 
+	client.confirmationWatcher = NewConfirmationWatcher(client)
+	client.replayJournal()
+
 	// Start broadcast handler
 	go client.handleBroadcasts()
 
 	return client
 }
 
-// GetSolanaTransaction fetches transaction details from Solana
-func (c *Client) GetSolanaTransaction(_, txHash string) (interface{}, error) {
-	// Prepare the JSON-RPC request
-	requestBody := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      1,
-		"method":  "getTransaction",
-		"params": []interface{}{
-			txHash,
-			map[string]interface{}{
-				"encoding":                       "json",
-				"maxSupportedTransactionVersion": 0,
-			},
-		},
+// replayJournal re-broadcasts any "signed" entry left over from a previous
+// process (safe, since it's already signed with a fixed nonce) and resumes
+// confirmation tracking for any "submitted" entry, so a crash between
+// signing and submitting - or between submitting and confirming - doesn't
+// leave a swap in an undefined state.
+func (c *Client) replayJournal() {
+	entries, err := c.journal.All()
+	if err != nil {
+		Logger.Printf("failed to read swap journal for replay: %v", err)
+		return
 	}
 
-	// Make the request with retries
-	var response interface{}
-	var err error
-	for i := 0; i < 10; i++ {
-		response, err = c.makeRPCRequest(SolanaRPC, requestBody)
-		if responseMap, ok := response.(map[string]interface{}); ok {
-			if responseMap["result"] == nil {
-				time.Sleep(5 * time.Second)
-				continue // Retry if result is nil
+	for _, entry := range entries {
+		switch entry.State {
+		case SwapStateSigned:
+			if entry.RawTx == "" {
+				continue
 			}
+			if _, err := c.resumeSignedSwap(entry); err != nil {
+				Logger.Printf("failed to re-broadcast signed swap %s on replay: %v", entry.Key, err)
+			}
+		case SwapStateSubmitted:
+			c.confirmationWatcher.Watch(entry.Chain, entry.TxHash)
 		}
+	}
+}
 
-		if err == nil {
-			break
-		}
+// resumeSignedSwap re-broadcasts a journaled "signed" entry's already-signed
+// RawTx instead of re-executing the swap from scratch. It's used both by
+// replayJournal after a process crash and by ExecuteSwap when a retry
+// arrives for an idempotency key that's already signed but not yet
+// submitted, so neither path reserves a second nonce for the same swap.
+func (c *Client) resumeSignedSwap(entry JournalEntry) (string, error) {
+	backend, ok := DefaultRegistry.Get(entry.Chain)
+	if !ok {
+		return "", fmt.Errorf("no backend registered for chain %s", entry.Chain)
 	}
+	txHash, err := backend.BroadcastSigned(context.Background(), entry.RawTx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Solana transaction after 10 retries: %w", err)
+		return "", err
 	}
-
-	return response, nil
+	c.journalState(entry.Key, entry.Chain, SwapStateSubmitted, entry.RawTx, txHash)
+	c.confirmationWatcher.Watch(entry.Chain, txHash)
+	return txHash, nil
 }
 
-// GetEthereumTransaction fetches transaction details from Ethereum
-func (c *Client) GetEthereumTransaction(_, txHash string) (interface{}, error) {
-	client, err := ethclient.Dial(EthereumRPC)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+// journalState is a no-op when key is empty, since idempotencyKey is
+// optional on ExecuteSwap.
+func (c *Client) journalState(key, chain string, state SwapState, rawTx, txHash string) {
+	if key == "" {
+		return
 	}
-	defer client.Close()
-
-	hash := common.HexToHash(txHash)
-	tx, isPending, err := client.TransactionByHash(context.Background(), hash)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get Ethereum transaction: %w", err)
+	if err := c.journal.Put(JournalEntry{
+		Key:       key,
+		Chain:     chain,
+		State:     state,
+		RawTx:     rawTx,
+		TxHash:    txHash,
+		UpdatedAt: time.Now(),
+	}); err != nil {
+		Logger.Printf("failed to journal swap %s: %v", key, err)
 	}
+}
 
-	// Convert transaction to map for consistent response format
-	return map[string]interface{}{
-		"hash":      tx.Hash().Hex(),
-		"value":     tx.Value().String(),
-		"gas":       tx.Gas(),
-		"gasPrice":  tx.GasPrice().String(),
-		"nonce":     tx.Nonce(),
-		"isPending": isPending,
-	}, nil
+// Close shuts down the Client's background subsystems, canceling any
+// in-flight confirmation watches.
+func (c *Client) Close() {
+	c.confirmationWatcher.Shutdown()
+}
+
+// GetSolanaTransaction fetches transaction details from Solana RPC
+// endpoint rpc. Kept for callers outside the ChainAdapter path; it simply
+// delegates to a new SolanaAdapter, not the one in the adapter registry.
+func (c *Client) GetSolanaTransaction(rpc, txHash string) (interface{}, error) {
+	return (&SolanaAdapter{}).GetTransaction(rpc, txHash)
+}
+
+// GetEthereumTransaction fetches transaction details from Ethereum RPC
+// endpoint rpc. Kept for callers outside the ChainAdapter path; it simply
+// delegates to a new "ethereum" EVMAdapter, not the one in the adapter
+// registry.
+func (c *Client) GetEthereumTransaction(rpc, txHash string) (interface{}, error) {
+	return (&EVMAdapter{chainName: "ethereum", nativeSymbol: "ETH", nativeDecimals: 18}).GetTransaction(rpc, txHash)
 }
 
 func (c *Client) makeRPCRequest(endpoint string, requestBody interface{}) (interface{}, error) {
+	return doJSONRPCRequestWithClient(c.http, endpoint, requestBody)
+}
+
+// doJSONRPCRequest POSTs requestBody to endpoint as JSON using a default
+// http.Client, for callers (like SolanaAdapter) that don't carry a
+// *Client of their own.
+func doJSONRPCRequest(endpoint string, requestBody interface{}) (interface{}, error) {
+	return doJSONRPCRequestWithClient(http.DefaultClient, endpoint, requestBody)
+}
+
+func doJSONRPCRequestWithClient(httpClient *http.Client, endpoint string, requestBody interface{}) (interface{}, error) {
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
 		return nil, err
@@ -186,7 +330,7 @@ func (c *Client) makeRPCRequest(endpoint string, requestBody interface{}) (inter
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.http.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -201,218 +345,247 @@ func (c *Client) makeRPCRequest(endpoint string, requestBody interface{}) (inter
 }
 
 func (c *Client) GetFile(id string) (*SolverFile, error) {
-	query := fmt.Sprintf(`{
-		"query": "query { getFileSolverApp(id: \"%s\") { solverFileId owner name payload } }"
-	}`, id)
-
-	req, err := http.NewRequest("POST", c.solverURL, bytes.NewBuffer([]byte(query)))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.http.Do(req)
+	file, err := c.solverGQL.GetFile(id)
 	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var result GraphQLResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error parsing response: %w", err)
+		return nil, err
 	}
-
-	return &result.Data.GetFileSolverApp, nil
+	return &SolverFile{
+		SolverFileId: file.SolverFileId,
+		Owner:        file.Owner,
+		Name:         file.Name,
+		Payload:      file.Payload,
+	}, nil
 }
 
 func (c *Client) GetTransactionByHash(hash string) (*Transaction, error) {
-	query := fmt.Sprintf(`{
-		"query": "query { getTransaction(hash: \"%s\") { 
-			hash
-			blockHash
-			blockNumber
-			from
-			to
-			value
-			gasPrice
-			gas
-			nonce
-			input
-			transactionIndex
-			v
-			r
-			s
-	 }}"
-	}`, hash)
-
-	req, err := http.NewRequest("POST", c.solverURL, bytes.NewBuffer([]byte(query)))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.http.Do(req)
+	tx, err := c.solverGQL.GetTransaction(hash)
 	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var result struct {
-		Data struct {
-			GetTransaction *Transaction `json:"getTransaction"`
-		} `json:"data"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error parsing response: %w", err)
+		return nil, err
 	}
-
-	return result.Data.GetTransaction, nil
+	if tx == nil {
+		return nil, nil
+	}
+	return &Transaction{
+		Hash:             tx.Hash,
+		BlockHash:        tx.BlockHash,
+		BlockNumber:      tx.BlockNumber,
+		From:             tx.From,
+		To:               tx.To,
+		Value:            tx.Value,
+		GasPrice:         tx.GasPrice,
+		Gas:              tx.Gas,
+		Nonce:            tx.Nonce,
+		Input:            tx.Input,
+		TransactionIndex: tx.TransactionIndex,
+		V:                tx.V,
+		R:                tx.R,
+		S:                tx.S,
+	}, nil
 }
 
 // CalculateSwap calculates swap details without executing the swap
 func (c *Client) CalculateSwap(fromToken, toToken string, amount float64) (*SwapResult, error) {
-	query := fmt.Sprintf(`{
-		"query": "query { calculateSwap(fromToken:\"%s\",toToken:\"%s\",amount:%f) { fromToken toToken fromAmount toAmount exchangeRate } }"
-	}`, fromToken, toToken, amount)
-
-	req, err := http.NewRequest("POST", c.solverURL, bytes.NewBuffer([]byte(query)))
+	result, err := c.solverGQL.CalculateSwap(fromToken, toToken, amount)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	// Execute request
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error executing request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Parse response
-	var result struct {
-		Data struct {
-			CalculateSwap struct {
-				FromToken    string  `json:"fromToken"`
-				ToToken      string  `json:"toToken"`
-				FromAmount   float64 `json:"fromAmount"`
-				ToAmount     float64 `json:"toAmount"`
-				ExchangeRate float64 `json:"exchangeRate"`
-			} `json:"calculateSwap"`
-		} `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-		} `json:"errors,omitempty"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
-	}
-
-	if len(result.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL error: %s", result.Errors[0].Message)
+		return nil, err
 	}
-
 	return &SwapResult{
-		FromToken:    result.Data.CalculateSwap.FromToken,
-		ToToken:      result.Data.CalculateSwap.ToToken,
-		FromAmount:   result.Data.CalculateSwap.FromAmount,
-		ToAmount:     result.Data.CalculateSwap.ToAmount,
-		ExchangeRate: result.Data.CalculateSwap.ExchangeRate,
+		FromToken:    result.FromToken,
+		ToToken:      result.ToToken,
+		FromAmount:   result.FromAmount,
+		ToAmount:     result.ToAmount,
+		ExchangeRate: result.ExchangeRate,
 	}, nil
 }
 
-// ExecuteSwap performs the swap operation
-func (c *Client) ExecuteSwap(fromToken, toToken string, amount float64, destinationAddress string) (*SwapResponse, error) {
-	// First calculate the swap
-	swapResult, err := c.CalculateSwap(fromToken, toToken, amount)
-	if err != nil {
-		return nil, fmt.Errorf("failed to calculate swap: %w", err)
+// ExecuteSwap performs the swap operation. It routes through RouteSwap so a
+// swap between tokens on different chains goes through the
+// bridge-then-pool/pool-then-bridge leg with the best expected output
+// instead of always assuming a single same-solver pool swap.
+//
+// idempotencyKey, if non-empty, makes retries from the frontend safe: if a
+// swap already reached "submitted" or later under that key, the recorded
+// result is returned instead of executing a duplicate swap.
+func (c *Client) ExecuteSwap(fromToken, toToken string, amount float64, destinationAddress, idempotencyKey string) (*SwapResponse, error) {
+	if idempotencyKey != "" {
+		if entry, ok := c.journal.Get(idempotencyKey); ok {
+			switch {
+			case entry.TxHash != "":
+				return &SwapResponse{
+					TxHash:             entry.TxHash,
+					Status:             string(entry.State),
+					DestinationAddress: destinationAddress,
+				}, nil
+			case entry.State == SwapStateSigned && entry.RawTx != "":
+				// A previous call already signed this swap (fixed nonce and
+				// all) but hadn't submitted it yet when this retry arrived -
+				// resume that in-flight tx instead of re-executing the leg,
+				// which would reserve a second nonce and sign a second tx.
+				txHash, err := c.resumeSignedSwap(entry)
+				if err != nil {
+					return nil, fmt.Errorf("failed to resume in-flight swap %s: %w", idempotencyKey, err)
+				}
+				return &SwapResponse{
+					TxHash:             txHash,
+					Status:             string(SwapStateSubmitted),
+					DestinationAddress: destinationAddress,
+				}, nil
+			}
+		}
 	}
 
-	// Execute the swap mutation
-	mutation := fmt.Sprintf(`{"query":"mutation calSwap{swap(fromToken:\"%s\",toToken:\"%s\",amount:\"%v\",destinationAddress:\"%s\")}"}`, fromToken, toToken, amount, destinationAddress)
-
-	req, err := http.NewRequest("POST", c.solverURL, bytes.NewBuffer([]byte(mutation)))
+	route, err := c.RouteSwap(fromToken, toToken, amount)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, fmt.Errorf("failed to find a swap route: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	var swapResponse *SwapResponse
+	for i, leg := range route.Legs {
+		legKey := idempotencyKey
+		if legKey != "" && len(route.Legs) > 1 {
+			legKey = fmt.Sprintf("%s:%d", idempotencyKey, i)
+		}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
-	}
-	defer resp.Body.Close()
+		legResponse, err := c.executeSwapLeg(leg, destinationAddress, legKey)
+		if err != nil {
+			return nil, fmt.Errorf("leg %d/%d on %s failed: %w", i+1, len(route.Legs), leg.Chain, err)
+		}
 
-	var rawResponse map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&rawResponse); err != nil {
-		return nil, fmt.Errorf("error parsing raw response: %w", err)
+		legData := map[string]interface{}{
+			"index":   i,
+			"of":      len(route.Legs),
+			"chain":   leg.Chain,
+			"status":  legResponse.Status,
+			"txHash":  legResponse.TxHash,
+			"routeOf": route.Kind,
+		}
+		c.broadcast <- WSMessage{Type: "swap.leg", Data: legData}
+		c.notifySwapSubscribers(legResponse.TxHash, legData)
+		swapResponse = legResponse
 	}
 
-	// Create properly structured result
-	var result struct {
-		Data   string `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-		} `json:"errors,omitempty"`
-	}
+	return swapResponse, nil
+}
 
-	// Re-encode and decode to ensure proper type conversion
-	jsonData, err := json.Marshal(rawResponse)
-	if err != nil {
-		return nil, fmt.Errorf("error re-encoding response: %w", err)
+// executeSwapLeg runs a single pool-swap leg (FromToken != ToToken) through
+// the solver's GraphQL swap mutation followed by the
+// prepare/sign/submit pipeline. Bridge legs (FromToken == ToToken) are
+// executed directly against DefaultBridgeRegistry instead.
+//
+// The leg settles on leg.Chain, not necessarily determineChain(leg.ToToken):
+// for a pool_then_bridge route, the pool swap settles on the source chain
+// and a later bridge leg moves the result to its destination, so leg.Chain
+// (computed by quoteBridgeRoute for exactly this reason) is what must drive
+// PrepareTransaction here.
+func (c *Client) executeSwapLeg(leg RouteLeg, destinationAddress, idempotencyKey string) (*SwapResponse, error) {
+	if leg.FromToken == leg.ToToken {
+		return c.executeBridgeLeg(leg, destinationAddress)
 	}
 
-	if err := json.Unmarshal(jsonData, &result); err != nil {
-		return nil, fmt.Errorf("error parsing structured response: %w", err)
+	chain := leg.Chain
+	c.journalState(idempotencyKey, chain, SwapStateCalculated, "", "")
+
+	swapResult, err := c.CalculateSwap(leg.FromToken, leg.ToToken, leg.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate swap: %w", err)
 	}
 
-	if len(result.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL error: %s", result.Errors[0].Message)
+	// Execute the swap mutation
+	txHash, err := c.solverGQL.Swap(graphql.SwapVariables{
+		FromToken:          leg.FromToken,
+		ToToken:            leg.ToToken,
+		Amount:             fmt.Sprintf("%v", leg.Amount),
+		DestinationAddress: destinationAddress,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	swapResponse := &SwapResponse{
-		TxHash:             result.Data,
+		TxHash:             txHash,
 		SwapResult:         *swapResult,
 		Status:             "pending",
 		DestinationAddress: destinationAddress,
 	}
 
 	// Prepare transaction for signing based on chain
-	chain := c.determineChain(toToken)
 	if err := c.PrepareTransaction(chain, swapResponse); err != nil {
 		return nil, fmt.Errorf("failed to prepare transaction: %w", err)
 	}
+	c.journalState(idempotencyKey, chain, SwapStatePrepared, "", "")
 
 	// Sign the prepared transaction
 	if err := c.SignTransaction(swapResponse); err != nil {
 		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
+	var rawTx string
+	if swapResponse.TxToSign != nil {
+		rawTx = swapResponse.TxToSign.RawTx
+	}
+	c.journalState(idempotencyKey, chain, SwapStateSigned, rawTx, "")
 
 	// Submit the signed transaction
 	if err := c.SubmitTransaction(swapResponse); err != nil {
+		c.journalState(idempotencyKey, chain, SwapStateFailed, rawTx, "")
 		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
+	c.journalState(idempotencyKey, chain, SwapStateSubmitted, rawTx, swapResponse.TxHash)
 
 	return swapResponse, nil
 }
 
+// executeBridgeLeg moves leg.Amount of leg.FromToken from the pool address
+// to destinationAddress across chains via whichever Bridge supports the
+// route, using the Client's configured Signer.
+//
+// leg.FromChain, not determineChain(leg.FromToken), is the bridge's source:
+// for a pool_then_bridge route this leg's token already sits on the swap's
+// source chain, which isn't necessarily that token's own home chain (e.g.
+// ARB resulting from an ETH->ARB pool swap settled on ethereum, not
+// arbitrum), so FromToken can't be used to re-derive it.
+func (c *Client) executeBridgeLeg(leg RouteLeg, destinationAddress string) (*SwapResponse, error) {
+	bridge, ok := DefaultBridgeRegistry.Get(leg.FromChain, leg.Chain)
+	if !ok {
+		return nil, fmt.Errorf("no bridge registered from %s to %s", leg.FromChain, leg.Chain)
+	}
+
+	fromAddress, err := c.getPoolAddress(leg.FromToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source pool address: %w", err)
+	}
+
+	txHash, err := bridge.Execute(context.Background(), c.signer, BridgeParams{
+		FromChain:   leg.FromChain,
+		ToChain:     leg.Chain,
+		FromAddress: fromAddress,
+		ToAddress:   destinationAddress,
+		Amount:      leg.Amount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bridge %s failed: %w", bridge.Name(), err)
+	}
+
+	return &SwapResponse{
+		TxHash:             txHash,
+		SwapResult:         SwapResult{FromToken: leg.FromToken, ToToken: leg.ToToken, FromAmount: leg.Amount, ToAmount: leg.Amount},
+		Status:             "submitted",
+		DestinationAddress: destinationAddress,
+	}, nil
+}
+
 func (c *Client) determineChain(token string) string {
-	switch token {
-	case "ETH":
-		return "ethereum"
-	case "SOL":
-		return "solana"
-	default:
-		return "unknown"
+	if meta, ok := knownTokens[token]; ok {
+		return meta.Chain
 	}
+	return "unknown"
 }
 
-// PrepareTransaction prepares a transaction for signing based on chain type
+// PrepareTransaction prepares a transaction for signing based on chain type.
+// Ethereum and Solana keep their own pool-transfer preparation below because
+// they need the ERC-20/SPL-aware calldata and fee-market handling that
+// ChainBackend.PrepareTransaction doesn't do; any other chain registered via
+// Registry.Register is picked up generically here without editing this
+// switch.
 func (c *Client) PrepareTransaction(chain string, swap *SwapResponse) error {
 	switch chain {
 	case "ethereum":
@@ -420,91 +593,60 @@ func (c *Client) PrepareTransaction(chain string, swap *SwapResponse) error {
 	case "solana":
 		return c.prepareSolanaTransaction(swap)
 	default:
-		return fmt.Errorf("unsupported chain: %s", chain)
+		return c.prepareGenericTransaction(chain, swap)
 	}
 }
 
-// GetAllPools fetches all pool addresses
-func (c *Client) GetAllPools() ([]Pool, error) {
-	query := `{"query":"query pools{getAllPools{chainName poolAddress}}"}`
-
-	req, err := http.NewRequest("POST", c.solverURL, bytes.NewBuffer([]byte(query)))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+// prepareGenericTransaction handles any chain beyond ethereum/solana by
+// delegating straight to its registered ChainBackend, the same way
+// CanonicalL2Bridge and MessageBridge already do for bridge legs. A backend
+// reached through this path is expected to hand back an already-signed
+// RawTx (it owns its own key material), so SignTransaction treats a
+// non-empty RawTx here as done rather than looking for an ethereum/solana
+// signer.
+func (c *Client) prepareGenericTransaction(chain string, swap *SwapResponse) error {
+	backend, ok := DefaultRegistry.Get(chain)
+	if !ok {
+		return fmt.Errorf("unsupported chain: %s", chain)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.http.Do(req)
+	fromAddress, err := c.getPoolAddress(swap.SwapResult.ToToken)
 	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var result struct {
-		Data struct {
-			GetAllPools []Pool `json:"getAllPools"`
-		} `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-		} `json:"errors,omitempty"`
+		return fmt.Errorf("failed to get source pool address: %w", err)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error parsing response: %w", err)
+	prep, err := backend.PrepareTransaction(context.Background(), fromAddress, swap.DestinationAddress, weiFromDecimalString(fmt.Sprintf("%v", swap.SwapResult.ToAmount)), nil)
+	if err != nil {
+		return fmt.Errorf("failed to prepare transaction on %s: %w", chain, err)
 	}
+	swap.TxToSign = prep
+	return nil
+}
 
-	if len(result.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL error: %s", result.Errors[0].Message)
+// GetAllPools fetches all pool addresses
+func (c *Client) GetAllPools() ([]Pool, error) {
+	pools, err := c.solverGQL.GetAllPools()
+	if err != nil {
+		return nil, err
 	}
-
-	// Accumulate pools from response
-	var pools []Pool
-	for _, pool := range result.Data.GetAllPools {
-		pools = append(pools, Pool{
-			ChainName:   pool.ChainName,
-			PoolAddress: pool.PoolAddress,
-		})
+	result := make([]Pool, len(pools))
+	for i, p := range pools {
+		result[i] = Pool{ChainName: p.ChainName, PoolAddress: p.PoolAddress}
 	}
-
-	return pools, nil
+	return result, nil
 }
 
 // GetAllPoolBalances fetches all pool balances
 func (c *Client) GetAllPoolBalances() ([]PoolBalance, error) {
-	query := `{"query":"query balances{getAllPoolBalances{poolAddress balance}}"}`
-
-	req, err := http.NewRequest("POST", c.solverURL, bytes.NewBuffer([]byte(query)))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.http.Do(req)
+	balances, err := c.solverGQL.GetAllPoolBalances()
 	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	var result struct {
-		Data struct {
-			GetAllPoolBalances []PoolBalance `json:"getAllPoolBalances"`
-		} `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-		} `json:"errors,omitempty"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("error parsing response: %w", err)
+		return nil, err
 	}
-
-	if len(result.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL error: %s", result.Errors[0].Message)
+	result := make([]PoolBalance, len(balances))
+	for i, b := range balances {
+		result[i] = PoolBalance{PoolAddress: b.PoolAddress, Balance: b.Balance}
 	}
-
-	return result.Data.GetAllPoolBalances, nil
+	return result, nil
 }
 
 // GetPool fetches pool address for a specific chain
@@ -530,43 +672,100 @@ func (c *Client) getPoolAddress(token string) (string, error) {
 }
 
 // Update the prepareEthereumTransaction method
-func (c *Client) prepareEthereumTransaction(swap *SwapResponse) error {
+func (c *Client) prepareEthereumTransaction(swap *SwapResponse) (err error) {
 	// Get pool address for the token
 	fromAddress, err := c.getPoolAddress(swap.SwapResult.ToToken)
 	if err != nil {
 		return fmt.Errorf("failed to get source pool address: %w", err)
 	}
 
-	// Query Ethereum node for current gas price
 	client, err := ethclient.Dial(EthereumRPC)
 	if err != nil {
 		return fmt.Errorf("failed to connect to Ethereum node: %w", err)
 	}
 	defer client.Close()
 
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	// Reserve the next nonce for the from address, guarding against two
+	// concurrent swaps racing on the same PendingNonceAt result.
+	nonce, err := defaultNonceManager.Reserve(context.Background(), "ethereum", fromAddress)
 	if err != nil {
-		return fmt.Errorf("failed to get gas price: %w", err)
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+	// EstimateGas or the gas price/tip lookups below can still fail after
+	// the nonce is reserved, which would otherwise leave a permanent gap in
+	// fromAddress's nonce sequence (Ethereum requires strictly sequential
+	// nonces, so every later transaction from this pool address would stall
+	// forever). Resync so the next Reserve re-seeds from the chain instead.
+	defer func() {
+		if err != nil {
+			defaultNonceManager.Resync("ethereum", fromAddress)
+		}
+	}()
+
+	chainParams := ChainParams{
+		FromAddress: fromAddress,
+		ToAddress:   swap.DestinationAddress,
+		Amount:      fmt.Sprintf("%f", swap.SwapResult.ToAmount),
+		GasLimit:    21000, // Standard ETH transfer gas limit
+		Nonce:       nonce,
 	}
 
-	// Get nonce for the from address
-	nonce, err := client.PendingNonceAt(context.Background(), common.HexToAddress(fromAddress))
-	if err != nil {
-		return fmt.Errorf("failed to get nonce: %w", err)
+	// An ERC-20 destination settles as a transfer(address,uint256) call
+	// against the token contract rather than a plain value transfer.
+	if token, ok := knownTokens[swap.SwapResult.ToToken]; ok && token.ContractAddress != "" {
+		amountWei := erc20AmountFromDecimal(swap.SwapResult.ToAmount, token.Decimals)
+
+		calldata, err := defaultERC20Client.TransferCalldata(swap.DestinationAddress, amountWei)
+		if err != nil {
+			return fmt.Errorf("failed to build ERC-20 transfer calldata: %w", err)
+		}
+
+		contractAddr := common.HexToAddress(token.ContractAddress)
+		fromAddr := common.HexToAddress(fromAddress)
+		calldataBytes, err := hexutil.Decode(calldata)
+		if err != nil {
+			return fmt.Errorf("failed to decode ERC-20 transfer calldata: %w", err)
+		}
+		gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
+			From: fromAddr,
+			To:   &contractAddr,
+			Data: calldataBytes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to estimate gas for ERC-20 transfer: %w", err)
+		}
+
+		chainParams.ToAddress = token.ContractAddress
+		chainParams.Amount = "0"
+		chainParams.Data = calldata
+		chainParams.GasLimit = gasLimit
+	}
+
+	// Prefer an EIP-1559 dynamic-fee transaction; fall back to legacy for
+	// chains whose latest block has no base fee (pre-London).
+	if header, err := client.HeaderByNumber(context.Background(), nil); err == nil && header.BaseFee != nil {
+		tip, err := client.SuggestGasTipCap(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get gas tip cap: %w", err)
+		}
+		feeCap := new(big.Int).Add(tip, new(big.Int).Mul(header.BaseFee, big.NewInt(2)))
+
+		chainParams.TxType = "dynamic"
+		chainParams.MaxPriorityFeePerGas = tip.String()
+		chainParams.MaxFeePerGas = feeCap.String()
+	} else {
+		gasPrice, err := client.SuggestGasPrice(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get gas price: %w", err)
+		}
+		chainParams.TxType = "legacy"
+		chainParams.GasPrice = gasPrice.String()
 	}
 
-	// Prepare transaction parameters
 	swap.TxToSign = &TransactionPrep{
-		Chain: "ethereum",
-		RawTx: "", // Will be filled by the signer
-		ChainParams: ChainParams{
-			FromAddress: fromAddress,
-			ToAddress:   swap.DestinationAddress,
-			Amount:      fmt.Sprintf("%f", swap.SwapResult.ToAmount),
-			GasPrice:    gasPrice.String(),
-			GasLimit:    21000, // Standard ETH transfer gas limit
-			Nonce:       nonce,
-		},
+		Chain:       "ethereum",
+		RawTx:       "", // Will be filled by the signer
+		ChainParams: chainParams,
 	}
 	return nil
 }
@@ -586,17 +785,36 @@ func (c *Client) prepareSolanaTransaction(swap *SwapResponse) error {
 		return fmt.Errorf("failed to get recent blockhash: %w", err)
 	}
 
-	// Prepare transaction parameters
+	chainParams := ChainParams{
+		FromAddress:     fromAddress,
+		ToAddress:       swap.DestinationAddress,
+		Amount:          fmt.Sprintf("%f", swap.SwapResult.ToAmount),
+		RecentBlockhash: resp.Value.Blockhash.String(),
+		Lamports:        swap.SwapResult.ToAmount,
+	}
+
+	// An SPL destination settles as a token-program transfer between
+	// associated token accounts rather than a system-program lamport
+	// transfer; the mint address travels in Extra so signSolanaTransaction
+	// can build the right instruction.
+	if token, ok := knownTokens[swap.SwapResult.ToToken]; ok && token.Chain == "solana" && token.ContractAddress != "" {
+		extra, err := json.Marshal(map[string]string{"spl_mint": token.ContractAddress})
+		if err != nil {
+			return fmt.Errorf("failed to encode SPL mint address: %w", err)
+		}
+		chainParams.Extra = extra
+		// Lamports doubles as "raw base units to transfer" for an SPL
+		// token; scale the decimal ToAmount by the token's own decimals
+		// here, the same way prepareEthereumTransaction scales via
+		// erc20AmountFromDecimal, instead of passing the unscaled decimal
+		// amount straight through to the token-program instruction.
+		chainParams.Lamports = float64(splAmountFromDecimal(swap.SwapResult.ToAmount, token.Decimals))
+	}
+
 	swap.TxToSign = &TransactionPrep{
-		Chain: "solana",
-		RawTx: "", // Will be filled by the signer
-		ChainParams: ChainParams{
-			FromAddress:     fromAddress,
-			ToAddress:       swap.DestinationAddress,
-			Amount:          fmt.Sprintf("%f", swap.SwapResult.ToAmount),
-			RecentBlockhash: resp.Value.Blockhash.String(),
-			Lamports:        swap.SwapResult.ToAmount,
-		},
+		Chain:       "solana",
+		RawTx:       "", // Will be filled by the signer
+		ChainParams: chainParams,
 	}
 	return nil
 }
@@ -613,66 +831,145 @@ func (c *Client) SignTransaction(swap *SwapResponse) error {
 	case "solana":
 		return c.signSolanaTransaction(swap)
 	default:
+		// prepareGenericTransaction's backend already returned a
+		// ready-to-broadcast RawTx, so there's nothing left to sign here.
+		if swap.TxToSign.RawTx != "" {
+			return nil
+		}
 		return fmt.Errorf("unsupported chain for signing: %s", swap.TxToSign.Chain)
 	}
 }
 
 func (c *Client) signEthereumTransaction(swap *SwapResponse) error {
-	// Get derived Ethereum key instead of environment variable
-	if chainKeys == nil || chainKeys.EthereumKey == nil {
-		return fmt.Errorf("ethereum private key not initialized")
+	params := swap.TxToSign.ChainParams
+	toAddress := common.HexToAddress(params.ToAddress)
+	amountWei := weiFromDecimalString(params.Amount)
+
+	var data []byte
+	if params.Data != "" {
+		decoded, err := hexutil.Decode(params.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decode transaction data: %w", err)
+		}
+		data = decoded
 	}
 
-	// Create the transaction object
-	tx := types.NewTransaction(
-		swap.TxToSign.ChainParams.Nonce,
-		common.HexToAddress(swap.TxToSign.ChainParams.ToAddress),
-		func() *big.Int {
-			// Convert decimal to integer by multiplying by 10^18 (standard ETH decimals)
-			amountFloat, _ := strconv.ParseFloat(swap.TxToSign.ChainParams.Amount, 64)
-			amountBigFloat := new(big.Float).SetFloat64(amountFloat)
-			multiplier := new(big.Float).SetFloat64(1e18)
-			result := new(big.Float).Mul(amountBigFloat, multiplier)
-
-			amountBigInt := new(big.Int)
-			result.Int(amountBigInt)
-			return amountBigInt
-		}(),
-		swap.TxToSign.ChainParams.GasLimit,
-		func() *big.Int {
-			gasPrice, _ := new(big.Int).SetString(swap.TxToSign.ChainParams.GasPrice, 10)
-			return gasPrice
-		}(),
-		nil, // data
-	)
+	var tx *types.Transaction
+	if params.TxType == "dynamic" {
+		tip, ok := new(big.Int).SetString(params.MaxPriorityFeePerGas, 10)
+		if !ok {
+			return fmt.Errorf("invalid max priority fee: %s", params.MaxPriorityFeePerGas)
+		}
+		feeCap, ok := new(big.Int).SetString(params.MaxFeePerGas, 10)
+		if !ok {
+			return fmt.Errorf("invalid max fee: %s", params.MaxFeePerGas)
+		}
+
+		client, err := ethclient.Dial(EthereumRPC)
+		if err != nil {
+			return fmt.Errorf("failed to connect to Ethereum node: %w", err)
+		}
+		defer client.Close()
 
-	// Get the signer
-	chainID := big.NewInt(1337) // mainnet, adjust as needed
-	signer := types.NewEIP155Signer(chainID)
+		chainID, err := client.ChainID(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get chain id: %w", err)
+		}
 
-	// Sign the transaction
-	signedTx, err := types.SignTx(tx, signer, chainKeys.EthereumKey)
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     params.Nonce,
+			GasTipCap: tip,
+			GasFeeCap: feeCap,
+			Gas:       params.GasLimit,
+			To:        &toAddress,
+			Value:     amountWei,
+			Data:      data,
+		})
+
+		signer := types.LatestSignerForChainID(chainID)
+		signedTx, err := c.signer.SignEthereum(context.Background(), signer, tx)
+		if err != nil {
+			return fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		return c.storeSignedEthereumTx(swap, signedTx)
+	}
+
+	gasPrice, ok := new(big.Int).SetString(params.GasPrice, 10)
+	if !ok {
+		return fmt.Errorf("invalid gas price: %s", params.GasPrice)
+	}
+	tx = types.NewTransaction(params.Nonce, toAddress, amountWei, params.GasLimit, gasPrice, data)
+
+	client, err := ethclient.Dial(EthereumRPC)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain id: %w", err)
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	signedTx, err := c.signer.SignEthereum(context.Background(), signer, tx)
 	if err != nil {
 		return fmt.Errorf("failed to sign transaction: %w", err)
 	}
+	return c.storeSignedEthereumTx(swap, signedTx)
+}
 
-	// Convert to raw bytes
+func (c *Client) storeSignedEthereumTx(swap *SwapResponse, signedTx *types.Transaction) error {
 	rawTxBytes, err := signedTx.MarshalBinary()
 	if err != nil {
 		return fmt.Errorf("failed to encode signed transaction: %w", err)
 	}
-
-	// Store the raw signed transaction
 	swap.TxToSign.RawTx = hexutil.Encode(rawTxBytes)
 	return nil
 }
 
-func (c *Client) signSolanaTransaction(swap *SwapResponse) error {
-	// Get derived Solana key instead of environment variable
-	if chainKeys == nil || chainKeys.SolanaKey == nil {
-		return fmt.Errorf("solana private key not initialized")
+// weiFromDecimalString converts a decimal ETH amount string (e.g. "1.5")
+// into wei by multiplying by 10^18.
+func weiFromDecimalString(amount string) *big.Int {
+	amountFloat, _ := strconv.ParseFloat(amount, 64)
+	result := new(big.Float).Mul(new(big.Float).SetFloat64(amountFloat), new(big.Float).SetFloat64(1e18))
+	amountBigInt := new(big.Int)
+	result.Int(amountBigInt)
+	return amountBigInt
+}
+
+// solanaTransferInstructions builds a native lamport transfer unless the
+// prepared transaction carries an "spl_mint" in Extra, in which case it
+// builds an SPL token-program transfer against the sender/recipient
+// associated token accounts.
+func (c *Client) solanaTransferInstructions(swap *SwapResponse, from, to solana.PublicKey) ([]solana.Instruction, error) {
+	if len(swap.TxToSign.ChainParams.Extra) == 0 {
+		return []solana.Instruction{
+			system.NewTransferInstruction(
+				uint64(swap.TxToSign.ChainParams.Lamports),
+				from,
+				to,
+			).Build(),
+		}, nil
+	}
+
+	var extra struct {
+		SPLMint string `json:"spl_mint"`
+	}
+	if err := json.Unmarshal(swap.TxToSign.ChainParams.Extra, &extra); err != nil {
+		return nil, fmt.Errorf("failed to decode SPL transfer extras: %w", err)
 	}
 
+	mint, err := solana.PublicKeyFromBase58(extra.SPLMint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPL mint address: %w", err)
+	}
+
+	return buildSPLTransferInstructions(context.Background(), rpc.New(SolanaRPC), mint, from, to, from, uint64(swap.TxToSign.ChainParams.Lamports))
+}
+
+func (c *Client) signSolanaTransaction(swap *SwapResponse) error {
 	from_address, err := solana.PublicKeyFromBase58(swap.TxToSign.ChainParams.FromAddress)
 	if err != nil {
 		return fmt.Errorf("failed to get from address: %w", err)
@@ -683,27 +980,33 @@ func (c *Client) signSolanaTransaction(swap *SwapResponse) error {
 		return fmt.Errorf("failed to get to address: %w", err)
 	}
 
+	instructions, err := c.solanaTransferInstructions(swap, from_address, to_address)
+	if err != nil {
+		return err
+	}
+
 	// Create a new transaction
 	tx, err := solana.NewTransaction(
-		[]solana.Instruction{
-			system.NewTransferInstruction(
-				uint64(swap.TxToSign.ChainParams.Lamports),
-				from_address,
-				to_address,
-			).Build(),
-		},
+		instructions,
 		solana.MustHashFromBase58(swap.TxToSign.ChainParams.RecentBlockhash),
 	)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction: %w", err)
+	}
 
-	// Sign the transaction
-	_, _ = tx.Sign(
-		func(key solana.PublicKey) *solana.PrivateKey {
-			if chainKeys.SolanaKey.PublicKey().Equals(key) {
-				return chainKeys.SolanaKey
-			}
-			return nil
-		},
-	)
+	// Sign the transaction via the pluggable signer, so KMS/HSM/remote
+	// backends can hold the Solana key instead of this process.
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal message for signing: %w", err)
+	}
+	sigBytes, err := c.signer.SignSolana(context.Background(), msg, from_address)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	var sig solana.Signature
+	copy(sig[:], sigBytes)
+	tx.Signatures = []solana.Signature{sig}
 
 	// Store the raw signed transaction
 	rawTx, err := tx.MarshalBinary()
@@ -727,79 +1030,66 @@ func (c *Client) SubmitTransaction(swap *SwapResponse) error {
 	case "solana":
 		return c.submitSolanaTransaction(swap)
 	default:
-		return fmt.Errorf("unsupported chain for submission: %s", swap.TxToSign.Chain)
+		return c.submitGenericTransaction(swap)
 	}
 }
 
-func (c *Client) submitEthereumTransaction(swap *SwapResponse) error {
-	// Connect to Ethereum node
-	client, err := ethclient.Dial(EthereumRPC)
-	if err != nil {
-		return fmt.Errorf("failed to connect to Ethereum node: %w", err)
+// submitGenericTransaction broadcasts through whichever ChainBackend
+// prepareGenericTransaction used, mirroring submitEthereumTransaction/
+// submitSolanaTransaction's own lookup-and-broadcast shape.
+func (c *Client) submitGenericTransaction(swap *SwapResponse) error {
+	chain := swap.TxToSign.Chain
+	backend, ok := DefaultRegistry.Get(chain)
+	if !ok {
+		return fmt.Errorf("%s backend not registered", chain)
 	}
-	defer client.Close()
 
-	// Decode raw transaction
-	rawTxBytes, err := hexutil.Decode(swap.TxToSign.RawTx)
+	txHash, err := backend.BroadcastSigned(context.Background(), swap.TxToSign.RawTx)
 	if err != nil {
-		return fmt.Errorf("failed to decode raw transaction: %w", err)
+		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	var tx types.Transaction
-	if err := tx.UnmarshalBinary(rawTxBytes); err != nil {
-		return fmt.Errorf("failed to unmarshal transaction: %w", err)
+	swap.TxHash = txHash
+	swap.Status = "submitted"
+	c.confirmationWatcher.Watch(chain, txHash)
+
+	return nil
+}
+
+func (c *Client) submitEthereumTransaction(swap *SwapResponse) error {
+	backend, ok := DefaultRegistry.Get("ethereum")
+	if !ok {
+		return fmt.Errorf("ethereum backend not registered")
 	}
 
-	// Submit transaction
-	if err := client.SendTransaction(context.Background(), &tx); err != nil {
+	txHash, err := backend.BroadcastSigned(context.Background(), swap.TxToSign.RawTx)
+	if err != nil {
 		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
 	// Update response with transaction hash
-	swap.TxHash = tx.Hash().Hex()
+	swap.TxHash = txHash
 	swap.Status = "submitted"
+	c.confirmationWatcher.Watch("ethereum", txHash)
 
 	return nil
 }
 
 func (c *Client) submitSolanaTransaction(swap *SwapResponse) error {
-	// Create RPC request
-	requestBody := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      1,
-		"method":  "sendTransaction",
-		"params": []interface{}{
-			swap.TxToSign.RawTx,
-			map[string]interface{}{
-				"encoding": "base58",
-			},
-		},
+	backend, ok := DefaultRegistry.Get("solana")
+	if !ok {
+		return fmt.Errorf("solana backend not registered")
 	}
 
-	// Submit transaction
-	response, err := c.makeRPCRequest(SolanaRPC, requestBody)
+	signature, err := backend.BroadcastSigned(context.Background(), swap.TxToSign.RawTx)
 	if err != nil {
 		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	// Extract transaction signature
-	result, ok := response.(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid response format")
-	}
-
-	if errMsg, hasError := result["error"]; hasError {
-		return fmt.Errorf("RPC error: %v", errMsg)
-	}
-
-	signature, ok := result["result"].(string)
-	if !ok {
-		return fmt.Errorf("invalid signature format in response")
-	}
-
 	// Update response with transaction signature
 	swap.TxHash = signature
 	swap.Status = "submitted"
+	c.confirmationWatcher.Watch("solana", signature)
 
 	return nil
 }
@@ -932,23 +1222,23 @@ func (c *Client) GetSolanaBalance(address string) (*Balance, error) {
 	}, nil
 }
 
-// GetEthereumBalance fetches ETH balance for an address
-func (c *Client) GetEthereumBalance(address string) (*Balance, error) {
+// GetEthereumBalance fetches the ETH balance for an address on chainKey,
+// using the pooled client DefaultEVMChainRegistry dialed at registration
+// instead of reconnecting on every call.
+func (c *Client) GetEthereumBalance(chainKey, address string) (*Balance, error) {
 	// Validate address
 	if !common.IsHexAddress(address) {
 		return nil, fmt.Errorf("invalid Ethereum address")
 	}
 
-	// Connect to Ethereum node
-	client, err := ethclient.Dial(EthereumRPC)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	entry, ok := DefaultEVMChainRegistry.Get(chainKey)
+	if !ok {
+		return nil, fmt.Errorf("unregistered chain: %s", chainKey)
 	}
-	defer client.Close()
 
 	// Get balance
 	account := common.HexToAddress(address)
-	balance, err := client.BalanceAt(context.Background(), account, nil)
+	balance, err := entry.client.BalanceAt(context.Background(), account, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
@@ -978,6 +1268,15 @@ type TransferParams struct {
 	Amount        string `json:"amount"`
 	BlobHash      string `json:"blobHash"`
 	NftId         string `json:"nftId"`
+
+	// PaymentTxHash/PaymentTokenAddress/BuyerAddress identify the buyer's
+	// ERC-20 payment transaction on Ethereum, set by processTxHash when it
+	// detected one. When PaymentTxHash is non-empty, ExecuteTransferMutation
+	// confirms that payment via ConfirmERC20Payment before finalizing the
+	// mint/transfer.
+	PaymentTxHash       string `json:"paymentTxHash,omitempty"`
+	PaymentTokenAddress string `json:"paymentTokenAddress,omitempty"`
+	BuyerAddress        string `json:"buyerAddress,omitempty"`
 }
 
 // Add this type to handle the transfer mutation response
@@ -991,65 +1290,45 @@ type TransferResponse struct {
 // Update NFTQueryResponse type to match new structure
 type NFTQueryResponse struct {
 	Data struct {
-		NftUsingBlobHash struct {
-			Token       string `json:"token"`
-			TokenId     string `json:"tokenId"`
-			Price       string `json:"price"`
-			ChainOwner  string `json:"chainOwner"`
-			ChainMinter string `json:"chainMinter"`
-			Name        string `json:"name"`
-			Owner       string `json:"owner"`
-			ID          int    `json:"id"`
-			Minter      string `json:"minter"`
-			Payload     []int  `json:"payload"`
-		} `json:"nftUsingBlobHash"`
+		NftUsingBlobHash graphql.NFT `json:"nftUsingBlobHash"`
 	} `json:"data"`
 }
 
-// Update GetNFTDetails function
+// GetNFTDetails fetches an NFT's details by its numeric blob hash id.
 func (c *Client) GetNFTDetails(id string) (*NFTQueryResponse, error) {
 	Logger.Printf("Fetching NFT details for blobHash: %s", id)
-	query := `{
-		"query": "query nft{nftUsingBlobHash(id:` + id + `){token tokenId price chainOwner chainMinter name owner id minter payload}}"
-	}`
 
-	req, err := http.NewRequest("POST", c.nonFungibleURL, bytes.NewBuffer([]byte(query)))
+	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		Logger.Printf("Error creating NFT query request: %v", err)
-		return nil, fmt.Errorf("error creating NFT query request: %w", err)
+		return nil, fmt.Errorf("invalid NFT id %q: %w", id, err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
+	nft, err := c.nonFungibleGQL.NftUsingBlobHash(idInt)
 	if err != nil {
 		Logger.Printf("Error executing NFT query: %v", err)
 		return nil, fmt.Errorf("error executing NFT query: %w", err)
 	}
-	defer resp.Body.Close()
 
 	var nftResp NFTQueryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&nftResp); err != nil {
-		Logger.Printf("Error parsing NFT query response: %v", err)
-		return nil, fmt.Errorf("error parsing NFT query response: %w", err)
-	}
+	nftResp.Data.NftUsingBlobHash = *nft
 	return &nftResp, nil
 }
 
-// Update ExecuteNFTContractTransaction to use the NFT ID
-func (c *Client) ExecuteNFTContractTransaction(tokenId int, calSwapAmount float64, listedPrice float64) (string, error) {
-	Logger.Printf("Executing NFT contract transaction for tokenId: %d", tokenId)
-	// Connect to Ethereum node
-	client, err := ethclient.Dial(EthereumRPC)
-	if err != nil {
-		Logger.Printf("Failed to connect to Ethereum node: %v", err)
-		return "", fmt.Errorf("failed to connect to Ethereum node: %w", err)
+// ExecuteNFTContractTransaction executes the NFT sale on chainKey's
+// marketplace contract, resolving the pooled client/contract binding and
+// network id through DefaultEVMChainRegistry instead of dialing and
+// hard-coding chain id 1337 on every call.
+func (c *Client) ExecuteNFTContractTransaction(chainKey string, tokenId int, calSwapAmount float64, listedPrice float64) (string, error) {
+	Logger.Printf("Executing NFT contract transaction for tokenId: %d on chain %s", tokenId, chainKey)
+
+	entry, ok := DefaultEVMChainRegistry.Get(chainKey)
+	if !ok {
+		return "", fmt.Errorf("unregistered chain: %s", chainKey)
+	}
+	if entry.nftBound == nil {
+		return "", fmt.Errorf("chain %s has no NFT contract registered", chainKey)
 	}
-	defer client.Close()
 
-	// Create contract instance
-	contractAddress := common.HexToAddress(NFTAddress)
-	contract := bind.NewBoundContract(contractAddress, marketplaceABI, client, client, client)
 	var amount float64
 	if calSwapAmount > listedPrice {
 		amount = listedPrice
@@ -1061,11 +1340,12 @@ func (c *Client) ExecuteNFTContractTransaction(tokenId int, calSwapAmount float6
 	amountFloat.Mul(amountFloat, new(big.Float).SetFloat64(1e18))
 	amountFloat.Int(amountWei)
 
-	// Create transaction
-	auth, err := bind.NewKeyedTransactorWithChainID(chainKeys.EthereumKey, big.NewInt(1337))
+	// Create an EIP-1559-aware transaction with a nonce reserved through
+	// the shared NonceManager, instead of a legacy gasPrice/gasLimit auth.
+	auth, err := defaultTxManager.PrepareAuth(context.Background(), chainKey, entry, chainKeys.EthereumKey)
 	if err != nil {
-		Logger.Printf("Failed to create auth: %v", err)
-		return "", fmt.Errorf("failed to create auth: %w", err)
+		Logger.Printf("Failed to prepare transaction: %v", err)
+		return "", fmt.Errorf("failed to prepare transaction: %w", err)
 	}
 	auth.Value = amountWei
 
@@ -1076,15 +1356,19 @@ func (c *Client) ExecuteNFTContractTransaction(tokenId int, calSwapAmount float6
 		return "", fmt.Errorf("failed to parse token ID: %d", tokenId)
 	}
 
-	// Execute sale transaction
-	tx, err := contract.Transact(auth, "executeSale", tokenIdInt)
+	// Execute sale transaction, resubmitting with a bumped tip if it sits
+	// unconfirmed too long.
+	tx, err := defaultTxManager.Submit(context.Background(), entry.client, auth, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return entry.nftBound.Transact(auth, "executeSale", tokenIdInt)
+	})
 	if err != nil {
 		Logger.Printf("Failed to execute sale: %v", err)
 		return "", fmt.Errorf("failed to execute sale: %w", err)
 	}
 
-	// Wait for transaction to be mined
-	_, err = bind.WaitMined(context.Background(), client, tx)
+	// Wait for the transaction to be mined and reach confirmation depth
+	// before reporting success.
+	_, err = defaultTxManager.WaitMinedWithConfirmations(context.Background(), entry.client, tx, ethConfirmationDepth)
 	if err != nil {
 		Logger.Printf("Failed to wait for transaction: %v", err)
 		return "", fmt.Errorf("failed to wait for transaction: %w", err)
@@ -1111,13 +1395,61 @@ func (c *Client) ExecuteTransferMutation(params TransferParams) (*TransferRespon
 		Logger.Printf("Failed to get NFT details: %v", err)
 		return nil, "", fmt.Errorf("failed to get NFT details: %w", err)
 	}
+
+	listedPrice := parseFloat64(nftDetails.Data.NftUsingBlobHash.Price)
+
+	// When processTxHash detected the buyer paying in an ERC-20 token,
+	// confirm that specific payment reached the seller, for at least the
+	// listed price scaled to the token's decimals, with enough
+	// confirmations to survive a reorg, before finalizing the mint/transfer
+	// below.
+	if params.PaymentTxHash != "" {
+		tokenDecimals, err := erc20Decimals(EthereumRPC, common.HexToAddress(params.PaymentTokenAddress))
+		if err != nil {
+			Logger.Printf("Failed to fetch payment token decimals: %v", err)
+			return nil, "", fmt.Errorf("failed to fetch payment token decimals: %w", err)
+		}
+
+		confirmCtx, cancel := context.WithTimeout(context.Background(), paymentConfirmationTimeout)
+		defer cancel()
+
+		if err := c.ConfirmERC20Payment(confirmCtx, PaymentWatchParams{
+			TxHash:        params.PaymentTxHash,
+			TokenAddress:  params.PaymentTokenAddress,
+			BuyerAddress:  params.BuyerAddress,
+			SellerAddress: nftDetails.Data.NftUsingBlobHash.Owner,
+			MinAmount:     erc20AmountFromDecimal(listedPrice, tokenDecimals),
+			Confirmations: ethConfirmationDepth,
+		}); err != nil {
+			Logger.Printf("Payment confirmation failed: %v", err)
+			return nil, "", fmt.Errorf("payment confirmation failed: %w", err)
+		}
+	}
+
 	var hash string
 	// After successful transfer mutation, if this is an ETH transfer, execute the NFT contract transaction
 	if params.ToToken == "ETH" {
-		hash, err = c.ExecuteNFTContractTransaction(nftDetails.Data.NftUsingBlobHash.ID, parseFloat64(params.Amount), parseFloat64(nftDetails.Data.NftUsingBlobHash.Price))
+		seller := nftDetails.Data.NftUsingBlobHash.Owner
+		amount := parseFloat64(params.Amount)
+		if amount > listedPrice {
+			amount = listedPrice
+		}
+
+		// Prefer settling through an already-open payment channel to this
+		// seller over a fresh executeSale transaction.
+		ticket, settled, err := c.settleThroughChannel(seller, weiFromDecimalString(fmt.Sprintf("%f", amount)))
 		if err != nil {
-			Logger.Printf("Failed to execute NFT contract transaction after transfer: %v", err)
-			return nil, "", fmt.Errorf("failed to execute NFT contract transaction after transfer: %w", err)
+			Logger.Printf("Failed to settle through payment channel after transfer: %v", err)
+			return nil, "", fmt.Errorf("failed to settle through payment channel after transfer: %w", err)
+		}
+		if settled {
+			hash = fmt.Sprintf("0x%x", ticket.ChannelID)
+		} else {
+			hash, err = c.ExecuteNFTContractTransaction("ethereum", nftDetails.Data.NftUsingBlobHash.ID, amount, listedPrice)
+			if err != nil {
+				Logger.Printf("Failed to execute NFT contract transaction after transfer: %v", err)
+				return nil, "", fmt.Errorf("failed to execute NFT contract transaction after transfer: %w", err)
+			}
 		}
 	}
 
@@ -1131,34 +1463,21 @@ func (c *Client) ExecuteTransferMutation(params TransferParams) (*TransferRespon
 		},
 	}
 
-	mutation := `{
-    "query": "mutation transfer{transfer(sourceOwner:\"` + params.SourceOwner + `\", tokenId:\"` + params.TokenId + `\", targetAccount: { chainId:\"` + params.TargetChainId + `\", owner:\"` + params.TargetOwner + `\"}, chainOwner:\"` + params.ChainOwner + `\", buyFromToken:\"` + params.BuyFromToken + `\",toToken:\"` + params.ToToken + `\", amount:\"` + fmt.Sprintf("%v", params.Amount) + `\")}"
-}`
-	req, err := http.NewRequest("POST", c.nonFungibleURL, bytes.NewBuffer([]byte(mutation)))
-	if err != nil {
-		Logger.Printf("Error creating transfer request: %v", err)
-		return nil, "", fmt.Errorf("error creating transfer request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
+	transferTxHash, err := c.nonFungibleGQL.Transfer(graphql.TransferVariables{
+		SourceOwner:   params.SourceOwner,
+		TokenId:       params.TokenId,
+		TargetChainId: params.TargetChainId,
+		TargetOwner:   params.TargetOwner,
+		ChainOwner:    params.ChainOwner,
+		BuyFromToken:  params.BuyFromToken,
+		ToToken:       params.ToToken,
+		Amount:        fmt.Sprintf("%v", params.Amount),
+	})
 	if err != nil {
 		Logger.Printf("Error executing transfer: %v", err)
 		return nil, "", fmt.Errorf("error executing transfer: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var transferResp TransferResponse
-	if err := json.NewDecoder(resp.Body).Decode(&transferResp); err != nil {
-		Logger.Printf("Error parsing transfer response: %v", err)
-		return nil, "", fmt.Errorf("error parsing transfer response: %w", err)
-	}
-
-	if len(transferResp.Errors) > 0 {
-		Logger.Printf("Transfer error: %s", transferResp.Errors[0].Message)
-		return nil, "", fmt.Errorf("transfer error: %s", transferResp.Errors[0].Message)
-	}
+	transferResp := TransferResponse{Data: transferTxHash}
 
 	c.broadcast <- WSMessage{
 		Type: "nft_transfer_completed",
@@ -1236,88 +1555,130 @@ const marketplaceABIJson = `[
       ],
       "stateMutability": "view",
       "type": "function"
+    },
+    {
+      "inputs": [
+        {
+          "internalType": "address",
+          "name": "owner",
+          "type": "address"
+        }
+      ],
+      "name": "balanceOf",
+      "outputs": [
+        {
+          "internalType": "uint256",
+          "name": "",
+          "type": "uint256"
+        }
+      ],
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "inputs": [
+        {
+          "internalType": "address",
+          "name": "owner",
+          "type": "address"
+        },
+        {
+          "internalType": "uint256",
+          "name": "index",
+          "type": "uint256"
+        }
+      ],
+      "name": "tokenOfOwnerByIndex",
+      "outputs": [
+        {
+          "internalType": "uint256",
+          "name": "",
+          "type": "uint256"
+        }
+      ],
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "inputs": [
+        {
+          "internalType": "uint256",
+          "name": "tokenId",
+          "type": "uint256"
+        }
+      ],
+      "name": "tokenURI",
+      "outputs": [
+        {
+          "internalType": "string",
+          "name": "",
+          "type": "string"
+        }
+      ],
+      "stateMutability": "view",
+      "type": "function"
+    },
+    {
+      "inputs": [
+        {
+          "internalType": "address[]",
+          "name": "owners",
+          "type": "address[]"
+        },
+        {
+          "internalType": "uint256[]",
+          "name": "ids",
+          "type": "uint256[]"
+        }
+      ],
+      "name": "balanceOfBatch",
+      "outputs": [
+        {
+          "internalType": "uint256[]",
+          "name": "",
+          "type": "uint256[]"
+        }
+      ],
+      "stateMutability": "view",
+      "type": "function"
     }
 ]`
 
 // Add function to publish data blob
 func (c *Client) PublishDataBlob(chainId string, imageBytes []byte) (string, error) {
-	Logger.Printf("Publishing data blob for chainId: %s", chainId)
-
-	// Convert bytes to array of integers
-	byteInts := make([]int, len(imageBytes))
-	for i, b := range imageBytes {
-		byteInts[i] = int(b)
-	}
-
-	mutation := fmt.Sprintf(`{
-		"query": "mutation datablob{publishDataBlob(chainId:\"%s\", bytes:%v)}"
-	}`, chainId, byteInts)
-
-	req, err := http.NewRequest("POST", c.lineraURL, bytes.NewBuffer([]byte(mutation)))
-	if err != nil {
-		Logger.Printf("Error creating publish blob request: %v", err)
-		return "", fmt.Errorf("error creating publish blob request: %w", err)
-	}
+	Logger.Printf("Publishing data blob for chainId: %s (%d bytes)", chainId, len(imageBytes))
 
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
+	blobHash, err := c.uploadBlobChunked(chainId, imageBytes)
 	if err != nil {
 		Logger.Printf("Error publishing blob: %v", err)
 		return "", fmt.Errorf("error publishing blob: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var blobResp DataBlobResponse
-	if err := json.NewDecoder(resp.Body).Decode(&blobResp); err != nil {
-		Logger.Printf("Error parsing blob response: %v", err)
-		return "", fmt.Errorf("error parsing blob response: %w", err)
-	}
-
-	if len(blobResp.Errors) > 0 {
-		Logger.Printf("Blob error: %s", blobResp.Errors[0].Message)
-		return "", fmt.Errorf("blob error: %s", blobResp.Errors[0].Message)
-	}
 
-	Logger.Printf("Successfully published data blob: %s", blobResp.Data.PublishDataBlob)
-	return blobResp.Data.PublishDataBlob, nil
+	Logger.Printf("Successfully published data blob: %s", blobHash)
+	return blobHash, nil
 }
 
 // Add function to mint NFT
 func (c *Client) MintNFT(params ListNFTParams, blobHash string, id int, token string) error {
 	Logger.Printf("Minting NFT with params: %+v, blobHash: %s", params, blobHash)
 
-	mutation := fmt.Sprintf(`{
-		"query": "mutation mint{mint(minter:\"%s\",name:\"%s\",blobHash:\"%s\",token:\"%s\",price:\"%s\",id:%d,chainMinter:\"%s\",chainOwner:\"%s\",description:\"%s\")}"
-	}`, params.Minter, params.Name, blobHash, token, params.Price, id, params.ChainMinter, params.ChainOwner, params.Description)
-
-	req, err := http.NewRequest("POST", c.nonFungibleURL, bytes.NewBuffer([]byte(mutation)))
-	if err != nil {
-		Logger.Printf("Error creating mint request: %v", err)
-		return fmt.Errorf("error creating mint request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
+	txHash, err := c.nonFungibleGQL.Mint(graphql.MintVariables{
+		Minter:      params.Minter,
+		Name:        params.Name,
+		BlobHash:    blobHash,
+		Token:       token,
+		Price:       params.Price,
+		ID:          id,
+		ChainMinter: params.ChainMinter,
+		ChainOwner:  params.ChainOwner,
+		Description: params.Description,
+	})
 	if err != nil {
 		Logger.Printf("Error minting NFT: %v", err)
 		return fmt.Errorf("error minting NFT: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var mintResp MintResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mintResp); err != nil {
-		Logger.Printf("Error parsing mint response: %v", err)
-		return fmt.Errorf("error parsing mint response: %w", err)
-	}
-
-	if len(mintResp.Errors) > 0 {
-		Logger.Printf("Mint error: %s", mintResp.Errors[0].Message)
-		return fmt.Errorf("mint error: %s", mintResp.Errors[0].Message)
-	}
 
-	Logger.Printf("Successfully minted NFT with transaction hash: %s", mintResp.Data)
+	Logger.Printf("Successfully minted NFT with transaction hash: %s", txHash)
 	return nil
 }
 
@@ -1342,42 +1703,81 @@ func (c *Client) ListNFT(params ListNFTParams) (string, error) {
 	return params.BlobHash, nil
 }
 
-// Add function to get all NFTs
+// GetAllNFTs returns every listed NFT keyed by id.
 func (c *Client) GetAllNFTs() (map[string]NFT, error) {
 	Logger.Println("Getting all NFTs")
 
-	query := `{
-		"query": "query nfts{nfts}"
-	}`
-
-	req, err := http.NewRequest("POST", c.nonFungibleURL, bytes.NewBuffer([]byte(query)))
-	if err != nil {
-		Logger.Printf("Error creating NFTs query request: %v", err)
-		return nil, fmt.Errorf("error creating NFTs query request: %w", err)
+	gqlNFTs, err := c.nonFungibleGQL.Nfts()
+	if err != nil {
+		Logger.Printf("Error fetching NFTs: %v", err)
+		return nil, fmt.Errorf("error fetching NFTs: %w", err)
+	}
+
+	nfts := make(map[string]NFT, len(gqlNFTs))
+	for id, n := range gqlNFTs {
+		nfts[id] = NFT{
+			TokenId:     n.TokenId,
+			Owner:       n.Owner,
+			Name:        n.Name,
+			Minter:      n.Minter,
+			Payload:     n.Payload,
+			Token:       n.Token,
+			Price:       n.Price,
+			ID:          n.ID,
+			ChainMinter: n.ChainMinter,
+			ChainOwner:  n.ChainOwner,
+		}
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	return nfts, nil
+}
 
-	resp, err := http.DefaultClient.Do(req)
+// GetBalance returns the number of NFTs owner holds across all classes,
+// mirroring the Cosmos x/nft gRPC Balance query.
+func (c *Client) GetBalance(owner string) (uint64, error) {
+	Logger.Printf("Fetching NFT balance for owner: %s", owner)
+
+	balance, err := c.nonFungibleGQL.Balance(owner)
 	if err != nil {
-		Logger.Printf("Error executing NFTs query: %v", err)
-		return nil, fmt.Errorf("error executing NFTs query: %w", err)
+		Logger.Printf("Error fetching NFT balance: %v", err)
+		return 0, fmt.Errorf("error fetching NFT balance: %w", err)
 	}
-	defer resp.Body.Close()
 
-	var nftsResp NFTsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&nftsResp); err != nil {
-		Logger.Printf("Error parsing NFTs response: %v", err)
-		return nil, fmt.Errorf("error parsing NFTs response: %w", err)
-	}
+	return balance, nil
+}
 
-	if len(nftsResp.Errors) > 0 {
-		Logger.Printf("NFTs query error: %s", nftsResp.Errors[0].Message)
-		return nil, fmt.Errorf("NFTs query error: %s", nftsResp.Errors[0].Message)
+// GetNFTsOfOwner returns owner's NFTs, optionally narrowed to a single
+// classID, mirroring the Cosmos x/nft gRPC NFTsOfOwner query. An empty
+// classID matches every class.
+func (c *Client) GetNFTsOfOwner(owner, classID string) ([]NFT, error) {
+	Logger.Printf("Fetching NFTs of owner: %s, classID: %s", owner, classID)
+
+	gqlNFTs, err := c.nonFungibleGQL.NftsOfOwner(graphql.NFTsOfOwnerVariables{
+		Owner:   owner,
+		ClassId: classID,
+	})
+	if err != nil {
+		Logger.Printf("Error fetching NFTs of owner: %v", err)
+		return nil, fmt.Errorf("error fetching NFTs of owner: %w", err)
+	}
+
+	nfts := make([]NFT, len(gqlNFTs))
+	for i, n := range gqlNFTs {
+		nfts[i] = NFT{
+			TokenId:     n.TokenId,
+			Owner:       n.Owner,
+			Name:        n.Name,
+			Minter:      n.Minter,
+			Payload:     n.Payload,
+			Token:       n.Token,
+			Price:       n.Price,
+			ID:          n.ID,
+			ChainMinter: n.ChainMinter,
+			ChainOwner:  n.ChainOwner,
+		}
 	}
 
-	// Logger.Printf("Successfully retrieved NFTs: %+v", nftsResp.Data.NFTs)
-	return nftsResp.Data.NFTs, nil
+	return nfts, nil
 }
 
 // ListNftForSale executes the listNftForSale mutation and creates an Ethereum transaction
@@ -1386,7 +1786,7 @@ func (c *Client) ListNftForSale(owner, chainId, tokenId, price, nftId, chainOwne
 		owner, chainId, tokenId, price)
 
 	// Execute Ethereum transaction to list the token
-	txHash, err := c.ListToken(nftId, price)
+	txHash, err := c.ListToken("ethereum", nftId, price)
 	if err != nil {
 		Logger.Printf("Error listing token on Ethereum: %v", err)
 		return nil, fmt.Errorf("error listing token on Ethereum: %w", err)
@@ -1396,45 +1796,18 @@ func (c *Client) ListNftForSale(owner, chainId, tokenId, price, nftId, chainOwne
 	Logger.Printf("Ethereum public address: %s", ethAddress)
 
 	// First, execute the mutation to list the NFT for sale on Linera
-	mutation := fmt.Sprintf(`{
-		"query": "mutation listNftForSale{listNftForSale(tokenId:\"%s\", chainOwner:\"%s\")}"
-	}`, tokenId, chainOwner)
-
-	req, err := http.NewRequest("POST", c.nonFungibleURL, bytes.NewBuffer([]byte(mutation)))
-	if err != nil {
-		Logger.Printf("Error creating request: %v", err)
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.http.Do(req)
+	lineraData, err := c.nonFungibleGQL.ListNftForSale(graphql.ListNftForSaleVariables{
+		TokenId:    tokenId,
+		ChainOwner: chainOwner,
+	})
 	if err != nil {
 		Logger.Printf("Error executing mutation: %v", err)
 		return nil, fmt.Errorf("error executing mutation: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var result struct {
-		Data   interface{} `json:"data"`
-		Errors []struct {
-			Message string `json:"message"`
-		} `json:"errors,omitempty"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		Logger.Printf("Error parsing response: %v", err)
-		return nil, fmt.Errorf("error parsing response: %w", err)
-	}
-
-	if len(result.Errors) > 0 {
-		Logger.Printf("Mutation error: %s", result.Errors[0].Message)
-		return nil, fmt.Errorf("mutation error: %s", result.Errors[0].Message)
-	}
 
 	// Return combined response
 	response := map[string]interface{}{
-		"lineraData": result.Data,
+		"lineraData": lineraData,
 		"ethereumTx": txHash,
 	}
 
@@ -1453,9 +1826,12 @@ func (c *Client) ListNftForSale(owner, chainId, tokenId, price, nftId, chainOwne
 	return response, nil
 }
 
-// ListToken creates an Ethereum transaction to list an NFT for sale
-func (c *Client) ListToken(tokenId string, price string) (string, error) {
-	Logger.Printf("Creating transaction to list NFT tokenId: %s for price: %s", tokenId, price)
+// ListToken creates an Ethereum transaction to list an NFT for sale on
+// chainKey, resolving the pooled client/contract binding and network id
+// through DefaultEVMChainRegistry instead of dialing and hard-coding chain
+// id 1337 on every call.
+func (c *Client) ListToken(chainKey, tokenId string, price string) (string, error) {
+	Logger.Printf("Creating transaction to list NFT tokenId: %s for price: %s on chain %s", tokenId, price, chainKey)
 
 	// Use the NFT ID from the query
 	tokenIdInt, ok := new(big.Int).SetString(tokenId, 10)
@@ -1469,22 +1845,18 @@ func (c *Client) ListToken(tokenId string, price string) (string, error) {
 	amountFloat.Mul(amountFloat, new(big.Float).SetFloat64(1e18))
 	amountFloat.Int(amountWei)
 
-	// Connect to Ethereum node
-	client, err := ethclient.Dial(EthereumRPC)
-	if err != nil {
-		Logger.Printf("Failed to connect to Ethereum node: %v", err)
-		return "", fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	entry, ok := DefaultEVMChainRegistry.Get(chainKey)
+	if !ok {
+		return "", fmt.Errorf("unregistered chain: %s", chainKey)
+	}
+	if entry.nftBound == nil {
+		return "", fmt.Errorf("chain %s has no NFT contract registered", chainKey)
 	}
-	defer client.Close()
-
-	// Create contract instance
-	contractAddress := common.HexToAddress(NFTAddress)
-	contract := bind.NewBoundContract(contractAddress, marketplaceABI, client, client, client)
 
 	// Get the listing price from the contract
 	var listPrice *big.Int
 	var result []interface{}
-	err = contract.Call(&bind.CallOpts{}, &result, "getListPrice")
+	err := entry.nftBound.Call(&bind.CallOpts{}, &result, "getListPrice")
 	if err != nil {
 		Logger.Printf("Failed to get listing price: %v", err)
 		return "", fmt.Errorf("failed to get listing price: %w", err)
@@ -1492,23 +1864,28 @@ func (c *Client) ListToken(tokenId string, price string) (string, error) {
 	if len(result) > 0 {
 		listPrice = result[0].(*big.Int)
 	}
-	// Create transaction
-	auth, err := bind.NewKeyedTransactorWithChainID(chainKeys.EthereumKey, big.NewInt(1337))
+	// Create an EIP-1559-aware transaction with a nonce reserved through
+	// the shared NonceManager, instead of a legacy gasPrice/gasLimit auth.
+	auth, err := defaultTxManager.PrepareAuth(context.Background(), chainKey, entry, chainKeys.EthereumKey)
 	if err != nil {
-		Logger.Printf("Failed to create auth: %v", err)
-		return "", fmt.Errorf("failed to create auth: %w", err)
+		Logger.Printf("Failed to prepare transaction: %v", err)
+		return "", fmt.Errorf("failed to prepare transaction: %w", err)
 	}
 	auth.Value = listPrice
 
-	// Execute list token transaction
-	tx, err := contract.Transact(auth, "listToken", tokenIdInt, amountWei)
+	// Execute list token transaction, resubmitting with a bumped tip if it
+	// sits unconfirmed too long.
+	tx, err := defaultTxManager.Submit(context.Background(), entry.client, auth, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return entry.nftBound.Transact(auth, "listToken", tokenIdInt, amountWei)
+	})
 	if err != nil {
 		Logger.Printf("Failed to execute list token transaction: %v", err)
 		return "", fmt.Errorf("failed to execute list token transaction: %w", err)
 	}
 
-	// Wait for transaction to be mined
-	_, err = bind.WaitMined(context.Background(), client, tx)
+	// Wait for the transaction to be mined and reach confirmation depth
+	// before reporting success.
+	_, err = defaultTxManager.WaitMinedWithConfirmations(context.Background(), entry.client, tx, ethConfirmationDepth)
 	if err != nil {
 		Logger.Printf("Failed to wait for transaction: %v", err)
 		return "", fmt.Errorf("failed to wait for transaction: %w", err)
@@ -1553,12 +1930,13 @@ func (c *Client) GetCurrentTokenID() (uint64, error) {
 
 // Add these WebSocket related methods
 func (c *Client) handleBroadcasts() {
+	wsLog := Logger.For("ws")
 	for msg := range c.broadcast {
 		c.clientsLock.RLock()
 		for client := range c.clients {
 			err := client.WriteJSON(msg)
 			if err != nil {
-				Logger.Printf("Error broadcasting to client: %v", err)
+				wsLog.Error("error broadcasting to client: %v", err)
 				client.Close()
 				delete(c.clients, client)
 			}
@@ -1568,10 +1946,12 @@ func (c *Client) handleBroadcasts() {
 }
 
 func (c *Client) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	wsLog := Logger.For("ws")
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := c.upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		Logger.Printf("Error upgrading to WebSocket: %v", err)
+		wsLog.Error("error upgrading to WebSocket: %v", err)
 		return
 	}
 
@@ -1580,14 +1960,38 @@ func (c *Client) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	c.clients[conn] = true
 	c.clientsLock.Unlock()
 
+	// keepaliveDone stops the ping ticker below once this connection closes.
+	keepaliveDone := make(chan struct{})
+
 	// Clean up on disconnect
 	defer func() {
+		close(keepaliveDone)
 		c.clientsLock.Lock()
 		delete(c.clients, conn)
 		c.clientsLock.Unlock()
+		c.removeSwapSubscriber(conn)
+		c.removeTopicSubscriber(conn)
 		conn.Close()
 	}()
 
+	// Send a "ping" every 30s so proxies/load balancers don't reap an
+	// otherwise-idle connection, and so a client can tell a hung
+	// connection apart from one that's simply quiet.
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := conn.WriteJSON(WSMessage{Type: "ping"}); err != nil {
+					return
+				}
+			case <-keepaliveDone:
+				return
+			}
+		}
+	}()
+
 	// Send initial connection message
 	conn.WriteJSON(WSMessage{
 		Type: "connected",
@@ -1600,7 +2004,7 @@ func (c *Client) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		err := conn.ReadJSON(&msg)
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				Logger.Printf("WebSocket error: %v", err)
+				wsLog.Error("websocket error: %v", err)
 			}
 			break
 		}
@@ -1612,6 +2016,18 @@ func (c *Client) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 				Type: "pong",
 				Data: "pong",
 			})
+		case "pong":
+			// keepalive acknowledgement for our own periodic "ping"; nothing to do.
+		case "log_level":
+			handleLogLevelMessage(conn, msg, wsLog)
+		case "connection_init":
+			// graphql-ws handshake: acknowledge before accepting "subscribe".
+			conn.WriteJSON(WSMessage{Type: "connection_ack"})
+		case "subscribe":
+			c.handleSubscribeMessage(conn, msg, wsLog)
+		case "complete":
+			c.unsubscribeSwap(conn, msg)
+			c.unsubscribeTopic(conn, msg)
 		default:
 			conn.WriteJSON(WSMessage{
 				Type:  "error",
@@ -1620,3 +2036,229 @@ func (c *Client) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+// handleSubscribeMessage implements the graphql-ws "subscribe" message,
+// which carries either a swapId (tx status/leg updates) or a topic (an
+// EventBus topic: nft.listed, nft.sold, nft.transferred, tx.confirmed):
+// {"type":"subscribe","data":{"id":"1","swapId":"0xabc..."}}
+// {"type":"subscribe","data":{"id":"2","topic":"nft.listed","filters":{"chainId":"..."},"since":42}}
+// Updates are pushed back as "next" messages instead of the ad-hoc
+// broadcast every client receives.
+func (c *Client) handleSubscribeMessage(conn *websocket.Conn, msg WSMessage, wsLog *SubsystemLogger) {
+	data, ok := msg.Data.(map[string]interface{})
+	if !ok {
+		conn.WriteJSON(WSMessage{Type: "error", Error: "subscribe requires id and swapId or topic"})
+		return
+	}
+	id, _ := data["id"].(string)
+	if id == "" {
+		conn.WriteJSON(WSMessage{Type: "error", Error: "subscribe requires an id"})
+		return
+	}
+
+	if topic, ok := data["topic"].(string); ok && topic != "" {
+		c.subscribeTopic(conn, id, topic, data)
+		wsLog.Info("subscribed %q to topic %q", id, topic)
+		return
+	}
+
+	swapID, _ := data["swapId"].(string)
+	if swapID == "" {
+		conn.WriteJSON(WSMessage{Type: "error", Error: "subscribe requires id and swapId or topic"})
+		return
+	}
+
+	c.SubscribeSwap(conn, id, swapID)
+	wsLog.Info("subscribed %q to swap %q updates", id, swapID)
+}
+
+// subscribeTopic registers conn on c.events for topic, optionally
+// restricted by a "filters" map of exact tag matches and replaying
+// buffered events newer than a "since" event ID, then starts a goroutine
+// pushing matching events back as "next" messages tagged with id.
+func (c *Client) subscribeTopic(conn *websocket.Conn, id, topic string, data map[string]interface{}) {
+	var filters map[string]string
+	if raw, ok := data["filters"].(map[string]interface{}); ok {
+		filters = make(map[string]string, len(raw))
+		for k, v := range raw {
+			if s, ok := v.(string); ok {
+				filters[k] = s
+			}
+		}
+	}
+	var since uint64
+	if s, ok := data["since"].(float64); ok && s > 0 {
+		since = uint64(s)
+	}
+
+	sub := c.events.Subscribe(topic, filters, since)
+
+	key := eventSubKey{conn: conn, id: id}
+	c.topicSubsLock.Lock()
+	c.topicSubs[key] = sub
+	c.topicSubsLock.Unlock()
+
+	go pumpEventSubscription(conn, id, sub)
+}
+
+// pumpEventSubscription forwards every event sub receives to conn as a
+// graphql-ws "next" message tagged with id, until sub is closed.
+func pumpEventSubscription(conn *websocket.Conn, id string, sub *Subscription) {
+	for {
+		select {
+		case event, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			conn.WriteJSON(WSMessage{
+				Type: "next",
+				Data: map[string]interface{}{
+					"id": id,
+					"payload": map[string]interface{}{
+						"topic":   event.Topic,
+						"eventId": event.ID,
+						"data":    event.Data,
+					},
+				},
+			})
+		case <-sub.Done():
+			return
+		}
+	}
+}
+
+// unsubscribeTopic implements the graphql-ws "complete" message for a topic
+// subscription: it closes the matching Subscription (which also stops its
+// pumpEventSubscription goroutine) and drops it from c.topicSubs. A no-op
+// if conn/id wasn't subscribed to a topic.
+func (c *Client) unsubscribeTopic(conn *websocket.Conn, msg WSMessage) {
+	data, ok := msg.Data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	id, _ := data["id"].(string)
+
+	key := eventSubKey{conn: conn, id: id}
+	c.topicSubsLock.Lock()
+	sub, ok := c.topicSubs[key]
+	delete(c.topicSubs, key)
+	c.topicSubsLock.Unlock()
+
+	if ok {
+		sub.Close()
+	}
+}
+
+// removeTopicSubscriber closes and drops every topic Subscription held by
+// conn, called when its WebSocket connection closes.
+func (c *Client) removeTopicSubscriber(conn *websocket.Conn) {
+	c.topicSubsLock.Lock()
+	var toClose []*Subscription
+	for key, sub := range c.topicSubs {
+		if key.conn == conn {
+			toClose = append(toClose, sub)
+			delete(c.topicSubs, key)
+		}
+	}
+	c.topicSubsLock.Unlock()
+
+	for _, sub := range toClose {
+		sub.Close()
+	}
+}
+
+// PublishEvent publishes data on topic to every subscribed WebSocket
+// client, tagged with tags for filter matching. Call it after a mutation
+// (listing, sale, transfer, confirmed tx) succeeds; a nil tags matches
+// only the subscribers on topic that have no filters.
+func (c *Client) PublishEvent(topic string, data interface{}, tags map[string]string) {
+	c.events.Publish(topic, data, tags)
+}
+
+// SubscribeSwap registers conn to receive "next" messages (the graphql-ws
+// convention) carrying swap.status/swap.leg updates for swapID, tagged with
+// the client-chosen subscription id so one connection can multiplex several
+// subscriptions.
+func (c *Client) SubscribeSwap(conn *websocket.Conn, id, swapID string) {
+	c.swapSubsLock.Lock()
+	defer c.swapSubsLock.Unlock()
+	c.swapSubs[swapID] = append(c.swapSubs[swapID], swapSubscription{conn: conn, id: id})
+}
+
+// unsubscribeSwap implements the graphql-ws "complete" message, removing a
+// single subscription id from conn.
+func (c *Client) unsubscribeSwap(conn *websocket.Conn, msg WSMessage) {
+	data, ok := msg.Data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	id, _ := data["id"].(string)
+
+	c.swapSubsLock.Lock()
+	defer c.swapSubsLock.Unlock()
+	for swapID, subs := range c.swapSubs {
+		kept := subs[:0]
+		for _, s := range subs {
+			if s.conn != conn || s.id != id {
+				kept = append(kept, s)
+			}
+		}
+		c.swapSubs[swapID] = kept
+	}
+}
+
+// removeSwapSubscriber drops every subscription held by conn, called when
+// its WebSocket connection closes.
+func (c *Client) removeSwapSubscriber(conn *websocket.Conn) {
+	c.swapSubsLock.Lock()
+	defer c.swapSubsLock.Unlock()
+	for swapID, subs := range c.swapSubs {
+		kept := subs[:0]
+		for _, s := range subs {
+			if s.conn != conn {
+				kept = append(kept, s)
+			}
+		}
+		c.swapSubs[swapID] = kept
+	}
+}
+
+// notifySwapSubscribers pushes a graphql-ws "next" message to every
+// connection subscribed to swapID.
+func (c *Client) notifySwapSubscribers(swapID string, data interface{}) {
+	c.swapSubsLock.RLock()
+	subs := append([]swapSubscription(nil), c.swapSubs[swapID]...)
+	c.swapSubsLock.RUnlock()
+
+	for _, s := range subs {
+		s.conn.WriteJSON(WSMessage{
+			Type: "next",
+			Data: map[string]interface{}{"id": s.id, "payload": map[string]interface{}{"data": data}},
+		})
+	}
+}
+
+// handleLogLevelMessage lets an operator flip a subsystem's log level at
+// runtime: {"type":"log_level","data":{"subsystem":"eth","level":"debug"}}.
+func handleLogLevelMessage(conn *websocket.Conn, msg WSMessage, wsLog *SubsystemLogger) {
+	data, ok := msg.Data.(map[string]interface{})
+	if !ok {
+		conn.WriteJSON(WSMessage{Type: "error", Error: "log_level requires subsystem and level"})
+		return
+	}
+
+	subsystem, _ := data["subsystem"].(string)
+	levelStr, _ := data["level"].(string)
+	level, err := ParseLevel(levelStr)
+	if err != nil || subsystem == "" {
+		conn.WriteJSON(WSMessage{Type: "error", Error: fmt.Sprintf("invalid log_level request: %v", err)})
+		return
+	}
+
+	Logger.SetLevel(subsystem, level)
+	wsLog.Info("subsystem %q log level set to %q", subsystem, levelStr)
+	conn.WriteJSON(WSMessage{
+		Type: "log_level",
+		Data: map[string]interface{}{"subsystem": subsystem, "level": levelStr},
+	})
+}
@@ -1,6 +1,10 @@
 package solver
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -9,8 +13,197 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/linera-protocol/examples/universal-solver/client/solver/graphql"
 )
 
+// stubBroadcastBackend is a ChainBackend stub that only needs to satisfy
+// BroadcastSigned, since TestExecuteSwapResumesInFlightSignedEntry only
+// exercises re-broadcasting an already-signed journal entry, never a live
+// prepare/sign.
+type stubBroadcastBackend struct {
+	name       string
+	txHash     string
+	broadcasts int
+}
+
+func (b *stubBroadcastBackend) Name() string { return b.name }
+
+func (b *stubBroadcastBackend) PrepareTransaction(ctx context.Context, from, to string, amount *big.Int, extra map[string]any) (*TransactionPrep, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (b *stubBroadcastBackend) BroadcastSigned(ctx context.Context, rawSignedTx string) (string, error) {
+	b.broadcasts++
+	return b.txHash, nil
+}
+
+func (b *stubBroadcastBackend) GetBalance(ctx context.Context, addr string) (Balance, error) {
+	return Balance{}, fmt.Errorf("not implemented")
+}
+
+func (b *stubBroadcastBackend) SubscribeConfirmations(ctx context.Context, txHash string) (<-chan Confirmation, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// TestExecuteSwapResumesInFlightSignedEntry guards against ExecuteSwap
+// re-executing a leg (and reserving a second nonce) when a retry arrives
+// for an idempotency key that's already signed but not yet submitted - it
+// should resume broadcasting the journaled RawTx instead.
+func TestExecuteSwapResumesInFlightSignedEntry(t *testing.T) {
+	backend := &stubBroadcastBackend{name: "chunk1-7-test-chain", txHash: "0xresumed"}
+	DefaultRegistry.Register(backend)
+
+	journal := newMemoryJournal()
+	// Create the client before the entry is journaled, so NewClient's
+	// replayJournal finds nothing and the resume happens inside ExecuteSwap.
+	client := NewClient("", "", "", WithJournal(journal))
+
+	const key = "retry-while-signed"
+	if err := journal.Put(JournalEntry{
+		Key:   key,
+		Chain: backend.name,
+		State: SwapStateSigned,
+		RawTx: "0xdeadbeef",
+	}); err != nil {
+		t.Fatalf("journal.Put() error = %v", err)
+	}
+
+	resp, err := client.ExecuteSwap("ETH", "ETH", 1.0, "0xdest", key)
+	if err != nil {
+		t.Fatalf("ExecuteSwap() error = %v", err)
+	}
+	if resp.TxHash != backend.txHash {
+		t.Errorf("ExecuteSwap() TxHash = %q, want %q", resp.TxHash, backend.txHash)
+	}
+	if backend.broadcasts != 1 {
+		t.Errorf("expected exactly one broadcast of the already-signed tx, got %d", backend.broadcasts)
+	}
+
+	entry, ok := journal.Get(key)
+	if !ok || entry.State != SwapStateSubmitted {
+		t.Errorf("expected journal entry to advance to %q, got %+v", SwapStateSubmitted, entry)
+	}
+}
+
+// stubArbitrumChainBackend is a ChainBackend stub standing in for a real
+// Arbitrum RPC/signer: it hands back an already-signed RawTx from
+// PrepareTransaction (as prepareGenericTransaction expects any non-ethereum/
+// solana backend to), so SignTransaction has nothing left to do.
+type stubArbitrumChainBackend struct {
+	prepares   int
+	broadcasts int
+}
+
+func (b *stubArbitrumChainBackend) Name() string { return "arbitrum" }
+
+func (b *stubArbitrumChainBackend) PrepareTransaction(ctx context.Context, from, to string, amount *big.Int, extra map[string]any) (*TransactionPrep, error) {
+	b.prepares++
+	return &TransactionPrep{
+		Chain: "arbitrum",
+		RawTx: fmt.Sprintf("0xarb-raw-%d", b.prepares),
+	}, nil
+}
+
+func (b *stubArbitrumChainBackend) BroadcastSigned(ctx context.Context, rawSignedTx string) (string, error) {
+	b.broadcasts++
+	return fmt.Sprintf("0xarb-tx-%d", b.broadcasts), nil
+}
+
+func (b *stubArbitrumChainBackend) GetBalance(ctx context.Context, addr string) (Balance, error) {
+	return Balance{}, fmt.Errorf("not implemented")
+}
+
+func (b *stubArbitrumChainBackend) SubscribeConfirmations(ctx context.Context, txHash string) (<-chan Confirmation, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// newRoutingStubServer answers calculateSwap, the swap mutation, and
+// getAllPools over a single batched GraphQL endpoint. The first
+// failAfterCalculateSwaps calculateSwap calls return a GraphQL error, so a
+// test can starve out the route kinds RouteSwap tries before the one it
+// wants to land on.
+func newRoutingStubServer(t *testing.T, failAfterCalculateSwaps int, rate float64) *httptest.Server {
+	t.Helper()
+	calculateSwapCalls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []graphql.Request
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("failed to decode batch request: %v", err)
+		}
+
+		resp := make([]graphql.Response, len(reqs))
+		for i, req := range reqs {
+			switch {
+			case strings.Contains(req.Query, "calculateSwap("):
+				calculateSwapCalls++
+				if calculateSwapCalls <= failAfterCalculateSwaps {
+					resp[i] = graphql.Response{Errors: []graphql.Error{{Message: "no direct pool for this pair"}}}
+					continue
+				}
+				amount, _ := req.Variables["amount"].(float64)
+				data, _ := json.Marshal(map[string]any{
+					"calculateSwap": map[string]any{
+						"fromToken":    req.Variables["fromToken"],
+						"toToken":      req.Variables["toToken"],
+						"fromAmount":   amount,
+						"toAmount":     amount * rate,
+						"exchangeRate": rate,
+					},
+				})
+				resp[i] = graphql.Response{Data: data}
+			case strings.Contains(req.Query, "mutation Swap"):
+				data, _ := json.Marshal(map[string]any{"swap": "0xsolver-swap-tx"})
+				resp[i] = graphql.Response{Data: data}
+			case strings.Contains(req.Query, "getAllPools"):
+				data, _ := json.Marshal(map[string]any{
+					"getAllPools": []graphql.Pool{{ChainName: "ETH", PoolAddress: "0xpoolETH"}},
+				})
+				resp[i] = graphql.Response{Data: data}
+			default:
+				t.Fatalf("unexpected query: %s", req.Query)
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+// TestExecuteSwapPoolThenBridgeReachesArbitrumBackend exercises ExecuteSwap
+// end to end (not just RouteSwap's quoting) for a pool_then_bridge route
+// whose every leg settles on arbitrum: the ARB->ETH pool swap settles on
+// arbitrum (leg.Chain, per quoteBridgeRoute), and the bridge leg that moves
+// the result to ethereum is executed via DefaultRegistry.Get(leg.FromChain),
+// which is also arbitrum. It guards against both halves of the routing bug:
+// executeSwapLeg routing a pool leg to determineChain(ToToken) instead of
+// leg.Chain, and PrepareTransaction having no execution path for a
+// registered non-ethereum/solana backend.
+func TestExecuteSwapPoolThenBridgeReachesArbitrumBackend(t *testing.T) {
+	// Starve out the direct route and the bridge_then_pool route (2 failed
+	// calculateSwap calls) so RouteSwap's only viable option is
+	// pool_then_bridge.
+	server := newRoutingStubServer(t, 2, 0.99)
+	defer server.Close()
+
+	backend := &stubArbitrumChainBackend{}
+	DefaultRegistry.Register(backend)
+
+	client := NewClient(server.URL, "", "")
+	// ExecuteSwap broadcasts a "swap.leg" update per leg over c.broadcast,
+	// which only has a reader once handleBroadcasts is running.
+	go client.handleBroadcasts()
+
+	resp, err := client.ExecuteSwap("ARB", "ETH", 1.0, "0xdest", "")
+	if err != nil {
+		t.Fatalf("ExecuteSwap(ARB, ETH) error = %v", err)
+	}
+	if resp.TxHash == "" {
+		t.Error("expected a non-empty TxHash from the final leg")
+	}
+	if backend.prepares == 0 || backend.broadcasts == 0 {
+		t.Errorf("expected the arbitrum backend to prepare and broadcast both legs, got prepares=%d broadcasts=%d", backend.prepares, backend.broadcasts)
+	}
+}
+
 func TestTokenIdURLEscapingWithQueryEscape(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -143,3 +336,92 @@ func TestWebSocketPingPong(t *testing.T) {
 		t.Errorf("Expected error 'Unknown message type', got '%s'", errorMsg.Error)
 	}
 }
+
+// TestCompleteUnsubscribesTopicSubscription covers the leak a topic
+// "subscribe" used to cause: nothing ever closed its Subscription or
+// removed it from topicSubs, so pumpEventSubscription ran forever and a
+// "complete" for it was silently ignored. After "complete", the bus must no
+// longer deliver to it and topicSubs must no longer hold it.
+func TestCompleteUnsubscribesTopicSubscription(t *testing.T) {
+	var client *Client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client.HandleWebSocket(w, r)
+	}))
+	defer server.Close()
+	client = NewClient("", "", "")
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("could not connect to WebSocket server: %v", err)
+	}
+	defer ws.Close()
+
+	var connectMsg WSMessage
+	if err := ws.ReadJSON(&connectMsg); err != nil {
+		t.Fatalf("failed to read connection message: %v", err)
+	}
+
+	if err := ws.WriteJSON(WSMessage{
+		Type: "subscribe",
+		Data: map[string]interface{}{"id": "1", "topic": "nft.listed"},
+	}); err != nil {
+		t.Fatalf("failed to send subscribe message: %v", err)
+	}
+
+	// Wait for subscribeTopic to register before publishing, so the first
+	// publish below isn't a race against it starting.
+	deadline := time.After(2 * time.Second)
+	for {
+		client.topicSubsLock.Lock()
+		n := len(client.topicSubs)
+		client.topicSubsLock.Unlock()
+		if n == 1 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for subscribeTopic to register")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	client.PublishEvent("nft.listed", "first", nil)
+
+	var next WSMessage
+	if err := ws.ReadJSON(&next); err != nil {
+		t.Fatalf("failed to read first next message: %v", err)
+	}
+	if next.Type != "next" {
+		t.Fatalf("Type = %q, want %q", next.Type, "next")
+	}
+
+	if err := ws.WriteJSON(WSMessage{
+		Type: "complete",
+		Data: map[string]interface{}{"id": "1"},
+	}); err != nil {
+		t.Fatalf("failed to send complete message: %v", err)
+	}
+
+	deadline = time.After(2 * time.Second)
+	for {
+		client.topicSubsLock.Lock()
+		n := len(client.topicSubs)
+		client.topicSubsLock.Unlock()
+		if n == 0 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for complete to remove the topic subscription")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	client.PublishEvent("nft.listed", "second", nil)
+
+	ws.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if err := ws.ReadJSON(&next); err == nil {
+		t.Fatalf("expected no further message after complete, got %+v", next)
+	}
+}
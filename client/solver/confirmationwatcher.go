@@ -0,0 +1,188 @@
+package solver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ethConfirmationDepth is how many blocks an Ethereum transaction needs
+// behind it before ConfirmationWatcher reports it as "confirmed".
+const ethConfirmationDepth = 12
+
+// pollBaseDelay and pollMaxDelay bound the delay between failed poll
+// attempts, which grows from pollBaseDelay up to pollMaxDelay.
+const (
+	pollBaseDelay = 2 * time.Second
+	pollMaxDelay  = 30 * time.Second
+)
+
+// watchEntry tracks one in-flight confirmation watch so ConfirmationWatcher
+// can avoid double-tracking a txHash and can cancel it on shutdown.
+type watchEntry struct {
+	cancel context.CancelFunc
+}
+
+// ConfirmationWatcher tracks submitted transactions until they reach a
+// configurable confirmation depth, detecting Ethereum reorgs by rechecking
+// a receipt's block hash against the canonical chain, and streams
+// "swap.status" updates to the Client's WebSocket broadcast channel.
+type ConfirmationWatcher struct {
+	client *Client
+
+	mu       sync.Mutex
+	watching map[string]*watchEntry
+}
+
+// NewConfirmationWatcher returns a watcher bound to client's broadcast
+// channel. Call Watch once per submitted tx hash.
+func NewConfirmationWatcher(client *Client) *ConfirmationWatcher {
+	return &ConfirmationWatcher{
+		client:   client,
+		watching: make(map[string]*watchEntry),
+	}
+}
+
+// Watch starts tracking txHash on chain, pushing swap.status broadcasts as
+// its confirmation count changes. It's a no-op if txHash is already being
+// watched. Only "ethereum" and "solana" are tracked; for any other chain no
+// swap.status broadcasts are sent.
+func (w *ConfirmationWatcher) Watch(chain, txHash string) {
+	w.mu.Lock()
+	if _, ok := w.watching[txHash]; ok {
+		w.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w.watching[txHash] = &watchEntry{cancel: cancel}
+	w.mu.Unlock()
+
+	go func() {
+		defer w.forget(txHash)
+		switch chain {
+		case "ethereum":
+			w.watchEthereum(ctx, txHash)
+		case "solana":
+			w.watchSolana(ctx, txHash)
+		}
+	}()
+}
+
+func (w *ConfirmationWatcher) forget(txHash string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.watching, txHash)
+}
+
+// Shutdown cancels every in-flight watch, used when the Client is closed.
+func (w *ConfirmationWatcher) Shutdown() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for txHash, entry := range w.watching {
+		entry.cancel()
+		delete(w.watching, txHash)
+	}
+}
+
+func (w *ConfirmationWatcher) publish(txHash, status string, confirmations uint64) {
+	data := map[string]interface{}{
+		"txHash":        txHash,
+		"status":        status,
+		"confirmations": confirmations,
+	}
+	w.client.broadcast <- WSMessage{Type: "swap.status", Data: data}
+	w.client.notifySwapSubscribers(txHash, data)
+}
+
+// watchEthereum polls the receipt for txHash, downgrading to "reorged" if a
+// previously-seen block hash stops matching the canonical chain.
+func (w *ConfirmationWatcher) watchEthereum(ctx context.Context, txHash string) {
+	client, err := ethclient.Dial(EthereumRPC)
+	if err != nil {
+		Logger.Printf("confirmation watcher: failed to connect to Ethereum node: %v", err)
+		return
+	}
+	defer client.Close()
+
+	hash := common.HexToHash(txHash)
+	var lastBlockHash common.Hash
+	delay := pollBaseDelay
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if err != nil {
+			if lastBlockHash != (common.Hash{}) {
+				// We'd previously seen a receipt and now it's gone: the
+				// block it was in was reorged out.
+				w.publish(txHash, "reorged", 0)
+				lastBlockHash = common.Hash{}
+			} else {
+				w.publish(txHash, "pending", 0)
+			}
+			delay = nextBackoff(delay)
+			continue
+		}
+		delay = pollBaseDelay
+
+		canonical, err := client.HeaderByNumber(ctx, receipt.BlockNumber)
+		if err != nil || canonical.Hash() != receipt.BlockHash {
+			w.publish(txHash, "reorged", 0)
+			lastBlockHash = common.Hash{}
+			continue
+		}
+		lastBlockHash = receipt.BlockHash
+
+		latest, err := client.BlockNumber(ctx)
+		if err != nil {
+			continue
+		}
+		confirmations := latest - receipt.BlockNumber.Uint64()
+
+		if receipt.Status == types.ReceiptStatusFailed {
+			w.publish(txHash, "failed", confirmations)
+			return
+		}
+		if confirmations >= ethConfirmationDepth {
+			w.publish(txHash, "confirmed", confirmations)
+			return
+		}
+		w.publish(txHash, "pending", confirmations)
+	}
+}
+
+// watchSolana polls GetSignatureStatuses until the signature reaches
+// CommitmentFinalized.
+func (w *ConfirmationWatcher) watchSolana(ctx context.Context, txHash string) {
+	backend, ok := DefaultRegistry.Get("solana")
+	if !ok {
+		Logger.Printf("confirmation watcher: solana backend not registered")
+		return
+	}
+
+	confirmations, err := backend.SubscribeConfirmations(ctx, txHash)
+	if err != nil {
+		Logger.Printf("confirmation watcher: failed to subscribe to %s: %v", txHash, err)
+		return
+	}
+	for c := range confirmations {
+		w.publish(c.TxHash, c.Status, c.Confirmations)
+	}
+}
+
+func nextBackoff(delay time.Duration) time.Duration {
+	next := delay * 2
+	if next > pollMaxDelay {
+		return pollMaxDelay
+	}
+	return next
+}
@@ -0,0 +1,53 @@
+package solver
+
+import "testing"
+
+func TestConfirmationWatcherDeduplicatesWatch(t *testing.T) {
+	w := NewConfirmationWatcher(&Client{broadcast: make(chan WSMessage, 1)})
+
+	w.mu.Lock()
+	w.watching["0xabc"] = &watchEntry{cancel: func() {}}
+	before := len(w.watching)
+	w.mu.Unlock()
+
+	w.Watch("ethereum", "0xabc")
+
+	w.mu.Lock()
+	after := len(w.watching)
+	w.mu.Unlock()
+
+	if after != before {
+		t.Fatalf("expected Watch to be a no-op for an already-tracked tx hash, got %d watchers, want %d", after, before)
+	}
+}
+
+func TestConfirmationWatcherShutdownCancelsAll(t *testing.T) {
+	w := NewConfirmationWatcher(&Client{broadcast: make(chan WSMessage, 1)})
+
+	canceled := false
+	w.mu.Lock()
+	w.watching["0xabc"] = &watchEntry{cancel: func() { canceled = true }}
+	w.mu.Unlock()
+
+	w.Shutdown()
+
+	if !canceled {
+		t.Fatal("expected Shutdown to cancel in-flight watches")
+	}
+	w.mu.Lock()
+	remaining := len(w.watching)
+	w.mu.Unlock()
+	if remaining != 0 {
+		t.Fatalf("expected Shutdown to clear the registry, got %d entries remaining", remaining)
+	}
+}
+
+func TestNextBackoffCapsAtMax(t *testing.T) {
+	delay := pollBaseDelay
+	for i := 0; i < 10; i++ {
+		delay = nextBackoff(delay)
+	}
+	if delay != pollMaxDelay {
+		t.Fatalf("expected backoff to cap at %v, got %v", pollMaxDelay, delay)
+	}
+}
@@ -0,0 +1,290 @@
+package solver
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Standard ERC-20 view/transfer selectors, keccak256 of the signature
+// truncated to 4 bytes.
+const (
+	selectorName        = "0x06fdde03"
+	selectorSymbol      = "0x95d89b41"
+	selectorDecimals    = "0x313ce567"
+	selectorTotalSupply = "0x18160ddd"
+	selectorBalanceOf   = "0x70a08231"
+	selectorTransfer    = "0xa9059cbb"
+)
+
+type erc20CacheKey struct {
+	chainId int64
+	token   common.Address
+}
+
+type erc20Meta struct {
+	symbol   string
+	decimals uint8
+}
+
+// ERC20Client resolves ERC-20 metadata and builds transfer calldata so NFT
+// listings can be priced and settled in arbitrary tokens instead of native
+// chain currency. Metadata is cached per (chainId, token) since symbol and
+// decimals never change for a deployed contract.
+type ERC20Client struct {
+	mu    sync.RWMutex
+	cache map[erc20CacheKey]erc20Meta
+}
+
+func NewERC20Client() *ERC20Client {
+	return &ERC20Client{
+		cache: make(map[erc20CacheKey]erc20Meta),
+	}
+}
+
+// defaultERC20Client is shared by the swap and NFT-pricing paths so
+// symbol/decimals lookups are cached across both.
+var defaultERC20Client = NewERC20Client()
+
+// erc20AmountFromDecimal scales a human-readable amount (e.g. 12.5 USDC)
+// up to the token's smallest unit using its decimals.
+func erc20AmountFromDecimal(amount float64, decimals uint8) *big.Int {
+	scaled := new(big.Float).Mul(big.NewFloat(amount), big.NewFloat(pow10(decimals)))
+	result := new(big.Int)
+	scaled.Int(result)
+	return result
+}
+
+func (e *ERC20Client) ethCall(ctx context.Context, rpcURL, token, selector string) ([]byte, error) {
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	data, err := hexutil.Decode(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %s: %w", selector, err)
+	}
+
+	tokenAddr := common.HexToAddress(token)
+	out, err := client.CallContract(ctx, ethereum.CallMsg{
+		To:   &tokenAddr,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("eth_call to %s failed: %w", selector, err)
+	}
+	return out, nil
+}
+
+// Name calls the contract's name() view method and decodes the ABI string.
+func (e *ERC20Client) Name(ctx context.Context, rpcURL, token string) (string, error) {
+	out, err := e.ethCall(ctx, rpcURL, token, selectorName)
+	if err != nil {
+		return "", err
+	}
+	return decodeABIString(out), nil
+}
+
+// Symbol calls symbol(), falling back to "" rather than an error for
+// contracts that don't implement it.
+func (e *ERC20Client) Symbol(ctx context.Context, rpcURL, token string) string {
+	out, err := e.ethCall(ctx, rpcURL, token, selectorSymbol)
+	if err != nil {
+		Logger.Printf("token %s has no symbol(): %v", token, err)
+		return ""
+	}
+	return decodeABIString(out)
+}
+
+// Decimals calls decimals(), returning the right-aligned uint8 result.
+func (e *ERC20Client) Decimals(ctx context.Context, rpcURL, token string) (uint8, error) {
+	out, err := e.ethCall(ctx, rpcURL, token, selectorDecimals)
+	if err != nil {
+		return 0, err
+	}
+	return uint8(decodeABIUint(out).Uint64()), nil
+}
+
+// TotalSupply calls totalSupply().
+func (e *ERC20Client) TotalSupply(ctx context.Context, rpcURL, token string) (*big.Int, error) {
+	out, err := e.ethCall(ctx, rpcURL, token, selectorTotalSupply)
+	if err != nil {
+		return nil, err
+	}
+	return decodeABIUint(out), nil
+}
+
+// metadata returns the cached (symbol, decimals) pair for a token,
+// resolving and caching it on first use.
+func (e *ERC20Client) metadata(ctx context.Context, chainId int64, rpcURL, token string) (erc20Meta, error) {
+	key := erc20CacheKey{chainId: chainId, token: common.HexToAddress(token)}
+
+	e.mu.RLock()
+	meta, ok := e.cache[key]
+	e.mu.RUnlock()
+	if ok {
+		return meta, nil
+	}
+
+	decimals, err := e.Decimals(ctx, rpcURL, token)
+	if err != nil {
+		return erc20Meta{}, fmt.Errorf("failed to resolve decimals for %s: %w", token, err)
+	}
+	meta = erc20Meta{
+		symbol:   e.Symbol(ctx, rpcURL, token),
+		decimals: decimals,
+	}
+
+	e.mu.Lock()
+	e.cache[key] = meta
+	e.mu.Unlock()
+
+	return meta, nil
+}
+
+// BalanceOf calls balanceOf(address) and scales the result by the token's
+// decimals, resolving and caching symbol/decimals along the way.
+func (e *ERC20Client) BalanceOf(ctx context.Context, chainId int64, rpcURL, token, owner string) (*Balance, error) {
+	meta, err := e.metadata(ctx, chainId, rpcURL, token)
+	if err != nil {
+		return nil, err
+	}
+
+	selectorData, err := hexutil.Decode(selectorBalanceOf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balanceOf selector: %w", err)
+	}
+	calldata := append(selectorData, common.LeftPadBytes(common.HexToAddress(owner).Bytes(), 32)...)
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	tokenAddr := common.HexToAddress(token)
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &tokenAddr, Data: calldata}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("balanceOf call failed: %w", err)
+	}
+
+	raw := decodeABIUint(out)
+	scaled := new(big.Float).Quo(
+		new(big.Float).SetInt(raw),
+		new(big.Float).SetFloat64(pow10(meta.decimals)),
+	)
+	amount, _ := scaled.Float64()
+
+	return &Balance{
+		Address: owner,
+		Amount:  amount,
+		Symbol:  meta.symbol,
+	}, nil
+}
+
+// TransferCalldata builds transfer(address,uint256) calldata for an ERC-20
+// settlement: the 4-byte selector followed by the 32-byte-padded recipient
+// and amount.
+func (e *ERC20Client) TransferCalldata(recipient string, amount *big.Int) (string, error) {
+	selectorData, err := hexutil.Decode(selectorTransfer)
+	if err != nil {
+		return "", fmt.Errorf("invalid transfer selector: %w", err)
+	}
+
+	calldata := make([]byte, 0, len(selectorData)+64)
+	calldata = append(calldata, selectorData...)
+	calldata = append(calldata, common.LeftPadBytes(common.HexToAddress(recipient).Bytes(), 32)...)
+	calldata = append(calldata, common.LeftPadBytes(amount.Bytes(), 32)...)
+
+	return hexutil.Encode(calldata), nil
+}
+
+// PrepareTransfer builds a TransactionPrep that settles an ERC-20 payment:
+// ToAddress is the token contract and Data carries the transfer calldata.
+func (e *ERC20Client) PrepareTransfer(ctx context.Context, chainId int64, rpcURL, token, from, recipient string, amount *big.Int) (prep *TransactionPrep, err error) {
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	nonce, err := defaultNonceManager.Reserve(ctx, "ethereum", from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	// Anything below can still fail after the nonce is reserved; resync so
+	// the next Reserve re-seeds from the chain instead of leaving a
+	// permanent gap that stalls every later transfer from from.
+	defer func() {
+		if err != nil {
+			defaultNonceManager.Resync("ethereum", from)
+		}
+	}()
+
+	calldata, err := e.TransferCalldata(recipient, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionPrep{
+		Chain: "ethereum",
+		RawTx: "",
+		ChainParams: ChainParams{
+			FromAddress: from,
+			ToAddress:   token,
+			Amount:      "0",
+			GasPrice:    gasPrice.String(),
+			GasLimit:    65000, // ERC-20 transfer typically costs more than a 21000 native transfer
+			Nonce:       nonce,
+			Data:        calldata,
+		},
+	}, nil
+}
+
+// decodeABIString decodes an ABI-encoded dynamic string return value: a
+// 32-byte offset, a 32-byte length, then the padded bytes. Non-UTF8 symbol
+// bytes (some tokens return bytes32 rather than string) fall back to hex.
+func decodeABIString(data []byte) string {
+	if len(data) < 64 {
+		return ""
+	}
+	length := decodeABIUint(data[32:64]).Uint64()
+	if uint64(len(data)) < 64+length {
+		return ""
+	}
+	raw := data[64 : 64+length]
+	if utf8.Valid(raw) {
+		return string(raw)
+	}
+	return "0x" + hex.EncodeToString(raw)
+}
+
+// decodeABIUint decodes a right-aligned 32-byte ABI word into a big.Int.
+func decodeABIUint(data []byte) *big.Int {
+	if len(data) < 32 {
+		return new(big.Int).SetBytes(data)
+	}
+	return new(big.Int).SetBytes(data[len(data)-32:])
+}
+
+func pow10(n uint8) float64 {
+	result := 1.0
+	for i := uint8(0); i < n; i++ {
+		result *= 10
+	}
+	return result
+}
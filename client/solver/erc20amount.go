@@ -0,0 +1,172 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ERC20TransferSelector and ERC20TransferFromSelector are the 4-byte
+// function selectors of the standard ERC-20 transfer(address,uint256) and
+// transferFrom(address,address,uint256) methods.
+const (
+	ERC20TransferSelector     = "a9059cbb"
+	ERC20TransferFromSelector = "23b872dd"
+)
+
+const erc20ABIJson = `[
+	{"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"type":"function"}
+]`
+
+var erc20ABI abi.ABI
+
+func init() {
+	var err error
+	erc20ABI, err = abi.JSON(strings.NewReader(erc20ABIJson))
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse erc20 ABI: %v", err))
+	}
+}
+
+// erc20AmountCacheKey keys erc20DecimalsCache by (rpc, token address)
+// rather than address alone: the same address can be a different,
+// CREATE2-deployed token contract on each of chunk3-4's registered EVM
+// chains, so address alone would return one chain's decimals() for
+// another's token at the same address.
+type erc20AmountCacheKey struct {
+	rpc   string
+	token common.Address
+}
+
+// erc20DecimalsCache memoizes decimals() by (rpc, token address): it's
+// immutable for a deployed token, so there's no reason to re-dial and
+// re-call it on every transaction.
+var (
+	erc20DecimalsCacheMu sync.Mutex
+	erc20DecimalsCache   = map[erc20AmountCacheKey]uint8{}
+)
+
+// DecodeERC20Transfer inspects a transaction's input data and reports the
+// raw token amount moved by a standard ERC-20 transfer(address,uint256) or
+// transferFrom(address,address,uint256) call. ok is false if input isn't
+// one of those two calls.
+func DecodeERC20Transfer(input []byte) (amount *big.Int, ok bool) {
+	if len(input) < 4 {
+		return nil, false
+	}
+	selector := common.Bytes2Hex(input[:4])
+	switch selector {
+	case ERC20TransferSelector:
+		// transfer(address to, uint256 amount): amount is the second
+		// 32-byte word.
+		if len(input) < 4+64 {
+			return nil, false
+		}
+		return new(big.Int).SetBytes(input[4+32 : 4+64]), true
+	case ERC20TransferFromSelector:
+		// transferFrom(address from, address to, uint256 amount): amount
+		// is the third 32-byte word.
+		if len(input) < 4+96 {
+			return nil, false
+		}
+		return new(big.Int).SetBytes(input[4+64 : 4+96]), true
+	default:
+		return nil, false
+	}
+}
+
+// erc20Decimals returns the decimals() of the ERC-20 token at tokenAddress
+// on the EVM-compatible node at rpc, caching the result since it never
+// changes for a deployed token.
+func erc20Decimals(rpc string, tokenAddress common.Address) (uint8, error) {
+	key := erc20AmountCacheKey{rpc: rpc, token: tokenAddress}
+
+	erc20DecimalsCacheMu.Lock()
+	decimals, cached := erc20DecimalsCache[key]
+	erc20DecimalsCacheMu.Unlock()
+	if cached {
+		return decimals, nil
+	}
+
+	client, err := ethclient.Dial(rpc)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to node: %w", err)
+	}
+	defer client.Close()
+
+	data, err := erc20ABI.Pack("decimals")
+	if err != nil {
+		return 0, fmt.Errorf("failed to pack decimals call: %w", err)
+	}
+
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &tokenAddress, Data: data}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call decimals() on %s: %w", tokenAddress.Hex(), err)
+	}
+
+	values, err := erc20ABI.Unpack("decimals", result)
+	if err != nil || len(values) == 0 {
+		return 0, fmt.Errorf("failed to unpack decimals() result from %s: %w", tokenAddress.Hex(), err)
+	}
+	decimals = values[0].(uint8)
+
+	erc20DecimalsCacheMu.Lock()
+	erc20DecimalsCache[key] = decimals
+	erc20DecimalsCacheMu.Unlock()
+
+	return decimals, nil
+}
+
+// erc20Symbol returns the symbol() of the ERC-20 token at tokenAddress on
+// the EVM-compatible node at rpc.
+func erc20Symbol(rpc string, tokenAddress common.Address) (string, error) {
+	client, err := ethclient.Dial(rpc)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to node: %w", err)
+	}
+	defer client.Close()
+
+	data, err := erc20ABI.Pack("symbol")
+	if err != nil {
+		return "", fmt.Errorf("failed to pack symbol call: %w", err)
+	}
+
+	result, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &tokenAddress, Data: data}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to call symbol() on %s: %w", tokenAddress.Hex(), err)
+	}
+
+	values, err := erc20ABI.Unpack("symbol", result)
+	if err != nil || len(values) == 0 {
+		return "", fmt.Errorf("failed to unpack symbol() result from %s: %w", tokenAddress.Hex(), err)
+	}
+	return values[0].(string), nil
+}
+
+// ERC20Decimals returns decimals() for tokenAddress on the default
+// Ethereum RPC endpoint.
+func (c *Client) ERC20Decimals(tokenAddress common.Address) (uint8, error) {
+	return erc20Decimals(EthereumRPC, tokenAddress)
+}
+
+// ERC20Symbol returns symbol() for tokenAddress on the default Ethereum RPC
+// endpoint.
+func (c *Client) ERC20Symbol(tokenAddress common.Address) (string, error) {
+	return erc20Symbol(EthereumRPC, tokenAddress)
+}
+
+// rawToFloat scales a raw token-unit integer down by 10^decimals.
+func rawToFloat(raw *big.Int, decimals uint8) float64 {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	scaled := new(big.Float).Quo(new(big.Float).SetInt(raw), scale)
+	f, _ := scaled.Float64()
+	return f
+}
@@ -0,0 +1,174 @@
+package solver
+
+import "sync"
+
+// eventReplayBufferSize bounds how many past events per topic EventBus
+// keeps around so Subscribe's since parameter can replay events a
+// reconnecting client missed.
+const eventReplayBufferSize = 256
+
+// eventSubscriberBufferSize bounds each Subscription's channel; once full,
+// Publish drops the oldest buffered event for that subscriber to make
+// room rather than blocking the publisher on a slow WebSocket client.
+const eventSubscriberBufferSize = 32
+
+// Event is one message published to an EventBus topic (nft.listed,
+// nft.sold, nft.transferred, tx.confirmed, ...). ID is a monotonically
+// increasing, per-topic sequence number: Subscribe(since) replays every
+// buffered event with ID > since before live events start arriving.
+type Event struct {
+	ID    uint64
+	Topic string
+	Data  interface{}
+	Tags  map[string]string
+}
+
+// Subscription is a live registration against one EventBus topic,
+// returned by EventBus.Subscribe. Events arrive on C; call Close once the
+// subscriber disconnects or unsubscribes.
+type Subscription struct {
+	topic     string
+	filters   map[string]string
+	c         chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+	bus       *EventBus
+}
+
+// C returns the channel this Subscription's matching events arrive on.
+func (s *Subscription) C() <-chan Event { return s.c }
+
+// Done is closed when the subscription is closed, so a pump goroutine
+// reading from C can stop selecting on it.
+func (s *Subscription) Done() <-chan struct{} { return s.done }
+
+// Close unregisters the subscription from its EventBus. Safe to call more
+// than once.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.bus.unsubscribe(s)
+		close(s.done)
+	})
+}
+
+// EventBus is an in-process pub/sub for domain events, fanning each
+// Publish out per topic to every matching Subscription with a bounded,
+// drop-oldest buffer per subscriber so one slow consumer can't stall
+// publishers or other subscribers.
+type EventBus struct {
+	mu          sync.Mutex
+	nextEventID map[string]uint64
+	subs        map[string][]*Subscription
+	replay      map[string][]Event
+}
+
+// NewEventBus returns an empty EventBus ready to Publish/Subscribe to.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		nextEventID: make(map[string]uint64),
+		subs:        make(map[string][]*Subscription),
+		replay:      make(map[string][]Event),
+	}
+}
+
+// Publish assigns data the topic's next monotonic event ID, keeps it in
+// the topic's replay buffer for late Subscribe(since) callers, and
+// fans it out to every live Subscription on topic whose filters match
+// tags.
+func (b *EventBus) Publish(topic string, data interface{}, tags map[string]string) Event {
+	b.mu.Lock()
+	b.nextEventID[topic]++
+	event := Event{ID: b.nextEventID[topic], Topic: topic, Data: data, Tags: tags}
+
+	ring := append(b.replay[topic], event)
+	if len(ring) > eventReplayBufferSize {
+		ring = ring[len(ring)-eventReplayBufferSize:]
+	}
+	b.replay[topic] = ring
+
+	subs := append([]*Subscription(nil), b.subs[topic]...)
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		if !matchesEventFilters(tags, sub.filters) {
+			continue
+		}
+		deliverEvent(sub.c, event)
+	}
+
+	return event
+}
+
+// deliverEvent sends event on c, dropping the oldest buffered event to
+// make room when c is full instead of blocking the publisher.
+func deliverEvent(c chan Event, event Event) {
+	select {
+	case c <- event:
+		return
+	default:
+	}
+
+	select {
+	case <-c:
+	default:
+	}
+	select {
+	case c <- event:
+	default:
+	}
+}
+
+// Subscribe registers a new Subscription to topic. filters restricts
+// delivery to events whose tags match every key in filters; a nil or
+// empty filters matches every event on the topic. since replays buffered
+// events with ID > since (0 skips replay and only delivers live events).
+func (b *EventBus) Subscribe(topic string, filters map[string]string, since uint64) *Subscription {
+	sub := &Subscription{
+		topic:   topic,
+		filters: filters,
+		c:       make(chan Event, eventSubscriberBufferSize),
+		done:    make(chan struct{}),
+		bus:     b,
+	}
+
+	b.mu.Lock()
+	b.subs[topic] = append(b.subs[topic], sub)
+	var backlog []Event
+	if since > 0 {
+		for _, event := range b.replay[topic] {
+			if event.ID > since && matchesEventFilters(event.Tags, filters) {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	for _, event := range backlog {
+		deliverEvent(sub.c, event)
+	}
+
+	return sub
+}
+
+func (b *EventBus) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := b.subs[sub.topic]
+	for i, s := range subs {
+		if s == sub {
+			b.subs[sub.topic] = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+}
+
+// matchesEventFilters reports whether tags satisfies every key/value pair
+// in filters; a nil or empty filters always matches.
+func matchesEventFilters(tags, filters map[string]string) bool {
+	for k, v := range filters {
+		if tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
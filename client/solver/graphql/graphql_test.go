@@ -0,0 +1,89 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientMintSendsTypedVariables(t *testing.T) {
+	var gotVariables map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []Request
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		gotVariables = reqs[0].Variables
+
+		data, _ := json.Marshal("0xabc")
+		json.NewEncoder(w).Encode([]Response{{Data: data}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	hash, err := client.Mint(MintVariables{
+		Minter: `mallory" injected`,
+		Name:   "cool nft",
+		ID:     7,
+	})
+	if err != nil {
+		t.Fatalf("Mint() error = %v", err)
+	}
+	if hash != "0xabc" {
+		t.Errorf("Mint() = %q, want %q", hash, "0xabc")
+	}
+
+	if gotVariables["minter"] != `mallory" injected` {
+		t.Errorf("variables[minter] = %v, want a quote-containing value carried verbatim, not escaped into the query text", gotVariables["minter"])
+	}
+	if gotVariables["id"] != float64(7) {
+		t.Errorf("variables[id] = %v, want 7", gotVariables["id"])
+	}
+}
+
+func TestClientPropagatesGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []Request
+		json.NewDecoder(r.Body).Decode(&reqs)
+		resp := make([]Response, len(reqs))
+		for i := range reqs {
+			resp[i] = Response{Errors: []Error{{Message: "boom"}}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	if _, err := client.Nfts(); err == nil {
+		t.Fatal("expected an error from a GraphQL errors response")
+	}
+}
+
+func TestClientBatchReturnsOneResponsePerOp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []Request
+		json.NewDecoder(r.Body).Decode(&reqs)
+		resp := make([]Response, len(reqs))
+		for i, req := range reqs {
+			data, _ := json.Marshal(map[string]any{"echo": req.Variables["value"]})
+			resp[i] = Response{Data: data}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ops := []Request{
+		{Query: "query Echo($value: String!) { echo(value: $value) }", Variables: map[string]any{"value": "a"}},
+		{Query: "query Echo($value: String!) { echo(value: $value) }", Variables: map[string]any{"value": "b"}},
+	}
+	responses, err := client.Batch(ops)
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("Batch() returned %d responses, want 2", len(responses))
+	}
+}
@@ -0,0 +1,357 @@
+package graphql
+
+import "encoding/json"
+
+// NFT is the shape returned by the nftUsingBlobHash and nfts queries.
+type NFT struct {
+	Token       string `json:"token"`
+	TokenId     string `json:"tokenId"`
+	Price       string `json:"price"`
+	ChainOwner  string `json:"chainOwner"`
+	ChainMinter string `json:"chainMinter"`
+	Name        string `json:"name"`
+	Owner       string `json:"owner"`
+	ID          int    `json:"id"`
+	Minter      string `json:"minter"`
+	Payload     []int  `json:"payload"`
+}
+
+// toVariables round-trips v through JSON so its struct tags become the
+// GraphQL variable names, without every caller hand-building a map.
+func toVariables(v any) map[string]any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var vars map[string]any
+	_ = json.Unmarshal(data, &vars)
+	return vars
+}
+
+// MintVariables are the arguments of the mint mutation.
+type MintVariables struct {
+	Minter      string `json:"minter"`
+	Name        string `json:"name"`
+	BlobHash    string `json:"blobHash"`
+	Token       string `json:"token"`
+	Price       string `json:"price"`
+	ID          int    `json:"id"`
+	ChainMinter string `json:"chainMinter"`
+	ChainOwner  string `json:"chainOwner"`
+	Description string `json:"description"`
+}
+
+const mintMutation = `mutation Mint($minter: String!, $name: String!, $blobHash: String!, $token: String!, $price: String!, $id: Int!, $chainMinter: String!, $chainOwner: String!, $description: String!) {
+	mint(minter: $minter, name: $name, blobHash: $blobHash, token: $token, price: $price, id: $id, chainMinter: $chainMinter, chainOwner: $chainOwner, description: $description)
+}`
+
+// Mint runs the mint mutation. The server returns the mutation's result
+// directly under "data" as a string, not nested under a "mint" key.
+func (c *Client) Mint(v MintVariables) (string, error) {
+	var result string
+	err := c.Do(mintMutation, toVariables(v), &result)
+	return result, err
+}
+
+// TransferVariables are the arguments of the transfer mutation.
+type TransferVariables struct {
+	SourceOwner   string `json:"sourceOwner"`
+	TokenId       string `json:"tokenId"`
+	TargetChainId string `json:"targetChainId"`
+	TargetOwner   string `json:"targetOwner"`
+	ChainOwner    string `json:"chainOwner"`
+	BuyFromToken  string `json:"buyFromToken"`
+	ToToken       string `json:"toToken"`
+	Amount        string `json:"amount"`
+}
+
+const transferMutation = `mutation Transfer($sourceOwner: String!, $tokenId: String!, $targetChainId: String!, $targetOwner: String!, $chainOwner: String!, $buyFromToken: String!, $toToken: String!, $amount: String!) {
+	transfer(sourceOwner: $sourceOwner, tokenId: $tokenId, targetAccount: { chainId: $targetChainId, owner: $targetOwner }, chainOwner: $chainOwner, buyFromToken: $buyFromToken, toToken: $toToken, amount: $amount)
+}`
+
+// Transfer runs the transfer mutation. Like Mint, the server returns the
+// result directly under "data" as a string.
+func (c *Client) Transfer(v TransferVariables) (string, error) {
+	var result string
+	err := c.Do(transferMutation, toVariables(v), &result)
+	return result, err
+}
+
+// ListNftForSaleVariables are the arguments of the listNftForSale mutation.
+type ListNftForSaleVariables struct {
+	TokenId    string `json:"tokenId"`
+	ChainOwner string `json:"chainOwner"`
+}
+
+const listNftForSaleMutation = `mutation ListNftForSale($tokenId: String!, $chainOwner: String!) {
+	listNftForSale(tokenId: $tokenId, chainOwner: $chainOwner)
+}`
+
+// ListNftForSale runs the listNftForSale mutation.
+func (c *Client) ListNftForSale(v ListNftForSaleVariables) (any, error) {
+	var result struct {
+		ListNftForSale any `json:"listNftForSale"`
+	}
+	err := c.Do(listNftForSaleMutation, toVariables(v), &result)
+	return result.ListNftForSale, err
+}
+
+const nftUsingBlobHashQuery = `query NftUsingBlobHash($id: Int!) {
+	nftUsingBlobHash(id: $id) { token tokenId price chainOwner chainMinter name owner id minter payload }
+}`
+
+// NftUsingBlobHash runs the nftUsingBlobHash query.
+func (c *Client) NftUsingBlobHash(id int) (*NFT, error) {
+	var result struct {
+		NftUsingBlobHash NFT `json:"nftUsingBlobHash"`
+	}
+	if err := c.Do(nftUsingBlobHashQuery, map[string]any{"id": id}, &result); err != nil {
+		return nil, err
+	}
+	return &result.NftUsingBlobHash, nil
+}
+
+// PublishDataBlobChunkVariables are the arguments of the
+// publishDataBlobChunk mutation.
+type PublishDataBlobChunkVariables struct {
+	ChainId string `json:"chainId"`
+	Root    string `json:"root"`
+	Index   int    `json:"index"`
+	Bytes   []int  `json:"bytes"`
+}
+
+const publishDataBlobChunkMutation = `mutation PublishDataBlobChunk($chainId: String!, $root: String!, $index: Int!, $bytes: [Int!]!) {
+	publishDataBlobChunk(chainId: $chainId, root: $root, index: $index, bytes: $bytes)
+}`
+
+// PublishDataBlobChunk uploads one chunk of a chunked blob upload.
+func (c *Client) PublishDataBlobChunk(v PublishDataBlobChunkVariables) error {
+	var result struct {
+		PublishDataBlobChunk bool `json:"publishDataBlobChunk"`
+	}
+	return c.Do(publishDataBlobChunkMutation, toVariables(v), &result)
+}
+
+// FinalizeDataBlobVariables are the arguments of the finalizeDataBlob
+// mutation.
+type FinalizeDataBlobVariables struct {
+	Root        string `json:"root"`
+	TotalChunks int    `json:"totalChunks"`
+}
+
+const finalizeDataBlobMutation = `mutation FinalizeDataBlob($root: String!, $totalChunks: Int!) {
+	finalizeDataBlob(root: $root, totalChunks: $totalChunks)
+}`
+
+// FinalizeDataBlob assembles the chunks previously uploaded under root and
+// returns the resulting blob hash.
+func (c *Client) FinalizeDataBlob(v FinalizeDataBlobVariables) (string, error) {
+	var result struct {
+		FinalizeDataBlob string `json:"finalizeDataBlob"`
+	}
+	err := c.Do(finalizeDataBlobMutation, toVariables(v), &result)
+	return result.FinalizeDataBlob, err
+}
+
+const balanceQuery = `query Balance($owner: String!) {
+	balance(owner: $owner)
+}`
+
+// Balance runs the balance query, returning the count of NFTs owner holds
+// across all classes.
+func (c *Client) Balance(owner string) (uint64, error) {
+	var result struct {
+		Balance uint64 `json:"balance"`
+	}
+	err := c.Do(balanceQuery, map[string]any{"owner": owner}, &result)
+	return result.Balance, err
+}
+
+// NFTsOfOwnerVariables are the arguments of the nftsOfOwner query. ClassId
+// is optional; an empty string means "every class".
+type NFTsOfOwnerVariables struct {
+	Owner   string `json:"owner"`
+	ClassId string `json:"classId,omitempty"`
+}
+
+const nftsOfOwnerQuery = `query NftsOfOwner($owner: String!, $classId: String) {
+	nftsOfOwner(owner: $owner, classId: $classId) { token tokenId price chainOwner chainMinter name owner id minter payload }
+}`
+
+// NftsOfOwner runs the nftsOfOwner query, optionally filtered to a single
+// class.
+func (c *Client) NftsOfOwner(v NFTsOfOwnerVariables) ([]NFT, error) {
+	var result struct {
+		NftsOfOwner []NFT `json:"nftsOfOwner"`
+	}
+	if err := c.Do(nftsOfOwnerQuery, toVariables(v), &result); err != nil {
+		return nil, err
+	}
+	return result.NftsOfOwner, nil
+}
+
+const nftsQuery = `query Nfts { nfts }`
+
+// Nfts runs the nfts query, returning every listed NFT keyed by id.
+func (c *Client) Nfts() (map[string]NFT, error) {
+	var result struct {
+		Nfts map[string]NFT `json:"nfts"`
+	}
+	if err := c.Do(nftsQuery, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Nfts, nil
+}
+
+// File is the shape returned by the getFileSolverApp query.
+type File struct {
+	SolverFileId string `json:"solverFileId"`
+	Owner        string `json:"owner"`
+	Name         string `json:"name"`
+	Payload      []byte `json:"payload"`
+}
+
+const getFileQuery = `query GetFile($id: String!) { getFileSolverApp(id: $id) { solverFileId owner name payload } }`
+
+// GetFile runs the getFileSolverApp query.
+func (c *Client) GetFile(id string) (*File, error) {
+	var result struct {
+		GetFileSolverApp File `json:"getFileSolverApp"`
+	}
+	if err := c.Do(getFileQuery, map[string]any{"id": id}, &result); err != nil {
+		return nil, err
+	}
+	return &result.GetFileSolverApp, nil
+}
+
+// Transaction is the shape returned by the getTransaction query.
+type Transaction struct {
+	Hash             string `json:"hash"`
+	BlockHash        string `json:"blockHash"`
+	BlockNumber      string `json:"blockNumber"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	Value            string `json:"value"`
+	GasPrice         string `json:"gasPrice"`
+	Gas              string `json:"gas"`
+	Nonce            string `json:"nonce"`
+	Input            string `json:"input"`
+	TransactionIndex string `json:"transactionIndex"`
+	V                string `json:"v"`
+	R                string `json:"r"`
+	S                string `json:"s"`
+}
+
+const getTransactionQuery = `query GetTransaction($hash: String!) { getTransaction(hash: $hash) {
+	hash
+	blockHash
+	blockNumber
+	from
+	to
+	value
+	gasPrice
+	gas
+	nonce
+	input
+	transactionIndex
+	v
+	r
+	s
+} }`
+
+// GetTransaction runs the getTransaction query.
+func (c *Client) GetTransaction(hash string) (*Transaction, error) {
+	var result struct {
+		GetTransaction *Transaction `json:"getTransaction"`
+	}
+	if err := c.Do(getTransactionQuery, map[string]any{"hash": hash}, &result); err != nil {
+		return nil, err
+	}
+	return result.GetTransaction, nil
+}
+
+// CalculateSwapResult is the shape returned by the calculateSwap query.
+type CalculateSwapResult struct {
+	FromToken    string  `json:"fromToken"`
+	ToToken      string  `json:"toToken"`
+	FromAmount   float64 `json:"fromAmount"`
+	ToAmount     float64 `json:"toAmount"`
+	ExchangeRate float64 `json:"exchangeRate"`
+}
+
+const calculateSwapQuery = `query CalculateSwap($fromToken: String!, $toToken: String!, $amount: Float!) {
+	calculateSwap(fromToken: $fromToken, toToken: $toToken, amount: $amount) {
+		fromToken toToken fromAmount toAmount exchangeRate
+	}
+}`
+
+// CalculateSwap runs the calculateSwap query.
+func (c *Client) CalculateSwap(fromToken, toToken string, amount float64) (*CalculateSwapResult, error) {
+	var result struct {
+		CalculateSwap CalculateSwapResult `json:"calculateSwap"`
+	}
+	variables := map[string]any{"fromToken": fromToken, "toToken": toToken, "amount": amount}
+	if err := c.Do(calculateSwapQuery, variables, &result); err != nil {
+		return nil, err
+	}
+	return &result.CalculateSwap, nil
+}
+
+// SwapVariables are the arguments of the swap mutation.
+type SwapVariables struct {
+	FromToken          string `json:"fromToken"`
+	ToToken            string `json:"toToken"`
+	Amount             string `json:"amount"`
+	DestinationAddress string `json:"destinationAddress"`
+}
+
+const swapMutation = `mutation Swap($fromToken: String!, $toToken: String!, $amount: String!, $destinationAddress: String!) {
+	swap(fromToken: $fromToken, toToken: $toToken, amount: $amount, destinationAddress: $destinationAddress)
+}`
+
+// Swap runs the swap mutation, returning the resulting transaction hash.
+func (c *Client) Swap(v SwapVariables) (string, error) {
+	var result struct {
+		Swap string `json:"swap"`
+	}
+	err := c.Do(swapMutation, toVariables(v), &result)
+	return result.Swap, err
+}
+
+// Pool is the shape returned by the getAllPools query.
+type Pool struct {
+	ChainName   string `json:"chainName"`
+	PoolAddress string `json:"poolAddress"`
+}
+
+const getAllPoolsQuery = `query Pools { getAllPools { chainName poolAddress } }`
+
+// GetAllPools runs the getAllPools query.
+func (c *Client) GetAllPools() ([]Pool, error) {
+	var result struct {
+		GetAllPools []Pool `json:"getAllPools"`
+	}
+	if err := c.Do(getAllPoolsQuery, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.GetAllPools, nil
+}
+
+// PoolBalance is the shape returned by the getAllPoolBalances query.
+type PoolBalance struct {
+	PoolAddress string  `json:"poolAddress"`
+	Balance     float64 `json:"balance"`
+}
+
+const getAllPoolBalancesQuery = `query Balances { getAllPoolBalances { poolAddress balance } }`
+
+// GetAllPoolBalances runs the getAllPoolBalances query.
+func (c *Client) GetAllPoolBalances() ([]PoolBalance, error) {
+	var result struct {
+		GetAllPoolBalances []PoolBalance `json:"getAllPoolBalances"`
+	}
+	if err := c.Do(getAllPoolBalancesQuery, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.GetAllPoolBalances, nil
+}
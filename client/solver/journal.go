@@ -0,0 +1,143 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// SwapState is a journal entry's position in the swap lifecycle, letting a
+// crash between two states resume from the last one durably recorded
+// instead of leaving the swap undefined.
+type SwapState string
+
+const (
+	SwapStateCalculated SwapState = "calculated"
+	SwapStatePrepared   SwapState = "prepared"
+	SwapStateSigned     SwapState = "signed"
+	SwapStateSubmitted  SwapState = "submitted"
+	SwapStateConfirmed  SwapState = "confirmed"
+	SwapStateFailed     SwapState = "failed"
+)
+
+// JournalEntry records one swap leg's progress, including the raw signed tx
+// once signed so a crash between signing and submission can be recovered
+// by re-broadcasting rather than re-signing with a new nonce.
+type JournalEntry struct {
+	Key       string    `json:"key"`
+	Chain     string    `json:"chain"`
+	State     SwapState `json:"state"`
+	RawTx     string    `json:"raw_tx,omitempty"`
+	TxHash    string    `json:"tx_hash,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Journal durably records swap state transitions so NewClient can replay
+// in-flight swaps after a crash.
+type Journal interface {
+	Put(entry JournalEntry) error
+	Get(key string) (JournalEntry, bool)
+	All() ([]JournalEntry, error)
+}
+
+// FileJournal is the default Journal: entries keyed by Key, persisted as a
+// single JSON file rewritten on every Put. That's enough durability for the
+// solver's write volume without pulling in an embedded database dependency.
+type FileJournal struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]JournalEntry
+}
+
+// NewFileJournal opens (or creates) the journal file at path, loading any
+// entries already recorded there.
+func NewFileJournal(path string) (*FileJournal, error) {
+	j := &FileJournal{path: path, entries: make(map[string]JournalEntry)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal file: %w", err)
+	}
+	if len(data) == 0 {
+		return j, nil
+	}
+	if err := json.Unmarshal(data, &j.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse journal file: %w", err)
+	}
+	return j, nil
+}
+
+func (j *FileJournal) Put(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries[entry.Key] = entry
+	data, err := json.MarshalIndent(j.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode journal: %w", err)
+	}
+	if err := os.WriteFile(j.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write journal file: %w", err)
+	}
+	return nil
+}
+
+func (j *FileJournal) Get(key string) (JournalEntry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry, ok := j.entries[key]
+	return entry, ok
+}
+
+func (j *FileJournal) All() ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := make([]JournalEntry, 0, len(j.entries))
+	for _, e := range j.entries {
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// memoryJournal is a non-persistent Journal, used as a fallback when the
+// default on-disk journal file can't be opened. It satisfies the Journal
+// interface so the rest of the swap pipeline doesn't need a nil check, but
+// offers no crash-safety.
+type memoryJournal struct {
+	mu      sync.Mutex
+	entries map[string]JournalEntry
+}
+
+func newMemoryJournal() *memoryJournal {
+	return &memoryJournal{entries: make(map[string]JournalEntry)}
+}
+
+func (j *memoryJournal) Put(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries[entry.Key] = entry
+	return nil
+}
+
+func (j *memoryJournal) Get(key string) (JournalEntry, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry, ok := j.entries[key]
+	return entry, ok
+}
+
+func (j *memoryJournal) All() ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := make([]JournalEntry, 0, len(j.entries))
+	for _, e := range j.entries {
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
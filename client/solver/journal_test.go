@@ -0,0 +1,61 @@
+package solver
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFileJournalRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swap_journal.json")
+
+	j, err := NewFileJournal(path)
+	if err != nil {
+		t.Fatalf("NewFileJournal() error = %v", err)
+	}
+
+	entry := JournalEntry{Key: "idem-1", Chain: "ethereum", State: SwapStateSigned, RawTx: "0xdead"}
+	if err := j.Put(entry); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	reopened, err := NewFileJournal(path)
+	if err != nil {
+		t.Fatalf("NewFileJournal() on reopen error = %v", err)
+	}
+	got, ok := reopened.Get("idem-1")
+	if !ok {
+		t.Fatal("expected entry to survive reopening the journal file")
+	}
+	if got.State != SwapStateSigned || got.RawTx != "0xdead" {
+		t.Errorf("Get() = %+v, want state %q rawTx %q", got, SwapStateSigned, "0xdead")
+	}
+}
+
+func TestNonceManagerIncrementsWithoutResync(t *testing.T) {
+	n := NewNonceManager()
+	n.nonces[nonceKey("ethereum", "0xabc")] = 5
+
+	first, err := n.Reserve(nil, "ethereum", "0xabc")
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+	second, err := n.Reserve(nil, "ethereum", "0xabc")
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+
+	if first != 5 || second != 6 {
+		t.Errorf("Reserve() sequence = %d, %d, want 5, 6", first, second)
+	}
+}
+
+func TestNonceManagerResyncClearsCache(t *testing.T) {
+	n := NewNonceManager()
+	n.nonces[nonceKey("ethereum", "0xabc")] = 5
+
+	n.Resync("ethereum", "0xabc")
+
+	if _, seeded := n.nonces[nonceKey("ethereum", "0xabc")]; seeded {
+		t.Error("expected Resync to clear the cached nonce")
+	}
+}
@@ -1,16 +1,258 @@
 package solver
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"log"
+	"log/syslog"
 	"os"
+	"sync"
+	"time"
 )
 
-var (
-	Logger *log.Logger // Global logger instance
+// Level is a logging severity, ordered so a subsystem configured at Level
+// only emits records at or above it.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
 )
 
-// InitLogger initializes the logger with custom configuration
-func InitLogger() {
-	// Create a logger with timestamp, file location, and line number
-	Logger = log.New(os.Stdout, "NFT-CLIENT: ", log.Ldate|log.Ltime|log.Lshortfile)
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel parses the level names accepted by LogConfig and the
+// "log_level" WebSocket admin message.
+func ParseLevel(s string) (Level, error) {
+	switch s {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level: %q", s)
+	}
+}
+
+// LogConfig configures InitLogger: the default level for subsystems that
+// haven't been overridden, the output format, and where records are
+// written.
+type LogConfig struct {
+	Level  Level
+	Format string // "text" (default) or "json"
+
+	// Sinks to write records to, in addition to the default stdout sink.
+	// Leave nil to just write to stdout.
+	Sinks []io.Writer
+
+	// RotateFilePath, if set, adds a size-based rotating file sink.
+	RotateFilePath string
+	RotateMaxBytes int64
+
+	// SyslogTag, if set, adds a syslog sink (unix only).
+	SyslogTag string
+}
+
+type record struct {
+	Time      time.Time `json:"time"`
+	Level     string    `json:"level"`
+	Subsystem string    `json:"subsystem"`
+	Message   string    `json:"message"`
+}
+
+// LoggerSet is the process-wide logging facility: per-subsystem loggers
+// that share sinks and formatting but can each be leveled independently.
+type LoggerSet struct {
+	mu       sync.RWMutex
+	cfg      LogConfig
+	out      io.Writer
+	loggers  map[string]*SubsystemLogger
+	levelOut *log.Logger // legacy-compatible sink for Printf/Println
+}
+
+// SubsystemLogger is a leveled logger scoped to one component (e.g. "ws",
+// "eth", "sol"), obtained via LoggerSet.For.
+type SubsystemLogger struct {
+	name  string
+	set   *LoggerSet
+	mu    sync.RWMutex
+	level Level
+}
+
+// Logger is the global logging facility, initialized by InitLogger.
+var Logger *LoggerSet
+
+// InitLogger initializes the global Logger from cfg. It replaces the
+// previous single-stdout *log.Logger so subsystems can be silenced or
+// re-leveled independently and records can be shipped to rotating files or
+// syslog instead of only stdout.
+func InitLogger(cfg LogConfig) {
+	sinks := append([]io.Writer{os.Stdout}, cfg.Sinks...)
+
+	if cfg.RotateFilePath != "" {
+		maxBytes := cfg.RotateMaxBytes
+		if maxBytes <= 0 {
+			maxBytes = 10 * 1024 * 1024 // 10MB default
+		}
+		sinks = append(sinks, newRotatingWriter(cfg.RotateFilePath, maxBytes))
+	}
+
+	if cfg.SyslogTag != "" {
+		if w, err := syslog.New(syslog.LOG_INFO, cfg.SyslogTag); err == nil {
+			sinks = append(sinks, w)
+		} else {
+			fmt.Fprintf(os.Stderr, "solver: failed to connect to syslog: %v\n", err)
+		}
+	}
+
+	out := io.MultiWriter(sinks...)
+	Logger = &LoggerSet{
+		cfg:      cfg,
+		out:      out,
+		loggers:  make(map[string]*SubsystemLogger),
+		levelOut: log.New(out, "NFT-CLIENT: ", log.Ldate|log.Ltime|log.Lshortfile),
+	}
+}
+
+// For returns the leveled logger for a subsystem, creating it (at the
+// LogConfig default level) on first use.
+func (l *LoggerSet) For(subsystem string) *SubsystemLogger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if s, ok := l.loggers[subsystem]; ok {
+		return s
+	}
+	s := &SubsystemLogger{name: subsystem, set: l, level: l.cfg.Level}
+	l.loggers[subsystem] = s
+	return s
+}
+
+// SetLevel changes a subsystem's level at runtime, e.g. in response to the
+// WebSocket "log_level" admin message.
+func (l *LoggerSet) SetLevel(subsystem string, level Level) {
+	s := l.For(subsystem)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.level = level
+}
+
+func (s *SubsystemLogger) write(level Level, format string, args ...interface{}) {
+	s.mu.RLock()
+	min := s.level
+	s.mu.RUnlock()
+	if level < min {
+		return
+	}
+
+	msg := fmt.Sprintf(format, args...)
+	if s.set.cfg.Format == "json" {
+		b, _ := json.Marshal(record{
+			Time:      time.Now(),
+			Level:     level.String(),
+			Subsystem: s.name,
+			Message:   msg,
+		})
+		fmt.Fprintln(s.set.out, string(b))
+		return
+	}
+	fmt.Fprintf(s.set.out, "%s [%s] [%s] %s\n", time.Now().Format(time.RFC3339), level.String(), s.name, msg)
+}
+
+func (s *SubsystemLogger) Debug(format string, args ...interface{}) {
+	s.write(LevelDebug, format, args...)
+}
+func (s *SubsystemLogger) Info(format string, args ...interface{}) {
+	s.write(LevelInfo, format, args...)
+}
+func (s *SubsystemLogger) Warn(format string, args ...interface{}) {
+	s.write(LevelWarn, format, args...)
+}
+func (s *SubsystemLogger) Error(format string, args ...interface{}) {
+	s.write(LevelError, format, args...)
+}
+
+// Printf/Println keep the pre-existing call sites (Logger.Printf(...))
+// compiling against the new LoggerSet; they write straight to the shared
+// "NFT-CLIENT: " logger, with no subsystem and no level filtering.
+func (l *LoggerSet) Printf(format string, args ...interface{}) {
+	l.levelOut.Output(2, fmt.Sprintf(format, args...))
+}
+
+func (l *LoggerSet) Println(args ...interface{}) {
+	l.levelOut.Output(2, fmt.Sprintln(args...))
+}
+
+// rotatingWriter is a size-based rotating file sink: once the current file
+// exceeds maxBytes, it's renamed to a ".1" suffix and a fresh file started.
+type rotatingWriter struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+func newRotatingWriter(path string, maxBytes int64) *rotatingWriter {
+	w := &rotatingWriter{path: path, maxBytes: maxBytes}
+	w.open()
+	return w
+}
+
+func (w *rotatingWriter) open() {
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "solver: failed to open log file %s: %v\n", w.path, err)
+		return
+	}
+	if info, err := f.Stat(); err == nil {
+		w.size = info.Size()
+	}
+	w.file = f
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return len(p), nil
+	}
+
+	if w.size+int64(len(p)) > w.maxBytes {
+		w.file.Close()
+		os.Rename(w.path, w.path+".1")
+		w.size = 0
+		w.open()
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func init() {
+	// Preserve the previous default behavior (plain stdout logging at
+	// info level) for callers that haven't adopted LogConfig yet.
+	InitLogger(LogConfig{Level: LevelInfo})
 }
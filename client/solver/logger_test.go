@@ -0,0 +1,65 @@
+package solver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSubsystemLoggerRespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	set := &LoggerSet{
+		cfg:     LogConfig{Level: LevelWarn},
+		out:     &buf,
+		loggers: make(map[string]*SubsystemLogger),
+	}
+
+	eth := set.For("eth")
+	eth.Debug("should not appear")
+	eth.Info("should not appear either")
+	eth.Warn("warning: %s", "low gas")
+
+	out := buf.String()
+	if strings.Contains(out, "should not appear") {
+		t.Errorf("expected debug/info below the configured warn level to be dropped, got: %s", out)
+	}
+	if !strings.Contains(out, "warning: low gas") {
+		t.Errorf("expected warn-level message to be written, got: %s", out)
+	}
+	if !strings.Contains(out, "[eth]") {
+		t.Errorf("expected subsystem name in output, got: %s", out)
+	}
+}
+
+func TestLoggerSetLevelAtRuntime(t *testing.T) {
+	var buf bytes.Buffer
+	set := &LoggerSet{
+		cfg:     LogConfig{Level: LevelError},
+		out:     &buf,
+		loggers: make(map[string]*SubsystemLogger),
+	}
+
+	ws := set.For("ws")
+	ws.Debug("dropped before SetLevel")
+	set.SetLevel("ws", LevelDebug)
+	ws.Debug("kept after SetLevel")
+
+	out := buf.String()
+	if strings.Contains(out, "dropped before SetLevel") {
+		t.Errorf("expected message logged before SetLevel to honor the old level, got: %s", out)
+	}
+	if !strings.Contains(out, "kept after SetLevel") {
+		t.Errorf("expected message logged after SetLevel to honor the new level, got: %s", out)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, ok := range []string{"debug", "info", "warn", "error"} {
+		if _, err := ParseLevel(ok); err != nil {
+			t.Errorf("ParseLevel(%q) returned error: %v", ok, err)
+		}
+	}
+	if _, err := ParseLevel("bogus"); err == nil {
+		t.Error("expected ParseLevel to reject an unknown level")
+	}
+}
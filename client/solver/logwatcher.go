@@ -0,0 +1,194 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// PaymentWatcher is implemented by backends that can confirm an off-chain
+// listed NFT was actually paid for before a mint/transfer is finalized.
+// EthBackend implements it via ERC-20 Transfer logs; a non-EVM chain would
+// provide whatever native equivalent it has (a program log, a memo, ...).
+type PaymentWatcher interface {
+	WatchPayment(ctx context.Context, params PaymentWatchParams) (<-chan WSMessage, error)
+}
+
+// PaymentWatchParams identifies the exact ERC-20 payment to confirm: the
+// buyer's own payment transaction hash, not just an amount threshold, so an
+// unrelated transfer to the same seller (a second listing's payment, a
+// larger incoming transfer) can never be mistaken for this one.
+type PaymentWatchParams struct {
+	TxHash        string
+	TokenAddress  string
+	BuyerAddress  string
+	SellerAddress string
+	MinAmount     *big.Int
+	Confirmations uint64
+}
+
+// LogWatcher confirms a specific ERC-20 payment by following its own
+// transaction receipt rather than scanning eth_getLogs for any Transfer
+// that happens to clear an amount threshold. It polls
+// eth_getTransactionReceipt rather than using eth_subscribe so it works
+// against any RPC endpoint, not just ones that support websocket
+// subscriptions.
+type LogWatcher struct {
+	rpcURL     string
+	pollPeriod time.Duration
+}
+
+func NewLogWatcher(rpcURL string) *LogWatcher {
+	return &LogWatcher{rpcURL: rpcURL, pollPeriod: 3 * time.Second}
+}
+
+// WatchPayment waits for params.TxHash to be mined, confirms its receipt
+// carries a Transfer(buyer, seller, value) log on params.TokenAddress with
+// value >= params.MinAmount, then pushes a "payment_confirmed" message once
+// that receipt has reached params.Confirmations blocks of depth. If the
+// transaction's block hash changes on a later poll (the chain reorged
+// around it), a "payment_reverted" message is pushed instead and watching
+// stops.
+func (w *LogWatcher) WatchPayment(ctx context.Context, params PaymentWatchParams) (<-chan WSMessage, error) {
+	client, err := ethclient.Dial(w.rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+
+	txHash := common.HexToHash(params.TxHash)
+	token := common.HexToAddress(params.TokenAddress)
+	buyer := common.HexToAddress(params.BuyerAddress)
+	seller := common.HexToAddress(params.SellerAddress)
+
+	out := make(chan WSMessage, 1)
+	go func() {
+		defer client.Close()
+		defer close(out)
+
+		var pendingLog *types.Log
+		var pendingBlockHash common.Hash
+		ticker := time.NewTicker(w.pollPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				receipt, err := client.TransactionReceipt(ctx, txHash)
+				if err != nil {
+					continue // not mined yet
+				}
+
+				if pendingLog == nil {
+					transferLog, err := findTransferLog(receipt.Logs, token, buyer, seller, params.MinAmount)
+					if err != nil {
+						out <- WSMessage{
+							Type: "payment_reverted",
+							Data: map[string]interface{}{
+								"txHash": params.TxHash,
+								"error":  err.Error(),
+							},
+						}
+						return
+					}
+					pendingLog = transferLog
+					pendingBlockHash = receipt.BlockHash
+				} else if receipt.BlockHash != pendingBlockHash {
+					out <- WSMessage{
+						Type: "payment_reverted",
+						Data: map[string]interface{}{
+							"txHash": params.TxHash,
+							"from":   buyer.Hex(),
+							"to":     seller.Hex(),
+						},
+					}
+					return
+				}
+
+				latest, err := client.BlockNumber(ctx)
+				if err != nil {
+					continue
+				}
+				if latest-receipt.BlockNumber.Uint64() < params.Confirmations {
+					continue
+				}
+
+				out <- WSMessage{
+					Type: "payment_confirmed",
+					Data: map[string]interface{}{
+						"txHash": params.TxHash,
+						"from":   buyer.Hex(),
+						"to":     seller.Hex(),
+						"amount": new(big.Int).SetBytes(pendingLog.Data).String(),
+					},
+				}
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// findTransferLog returns the Transfer(buyer, seller, value>=minAmount) log
+// on token within logs, or an error if none matches.
+func findTransferLog(logs []*types.Log, token, buyer, seller common.Address, minAmount *big.Int) (*types.Log, error) {
+	for _, log := range logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transferEventTopic {
+			continue
+		}
+		if common.BytesToAddress(log.Topics[1].Bytes()) != buyer {
+			continue
+		}
+		if common.BytesToAddress(log.Topics[2].Bytes()) != seller {
+			continue
+		}
+		if new(big.Int).SetBytes(log.Data).Cmp(minAmount) < 0 {
+			continue
+		}
+		return log, nil
+	}
+	return nil, fmt.Errorf("no Transfer(%s -> %s) log of at least %s found on %s", buyer.Hex(), seller.Hex(), minAmount.String(), token.Hex())
+}
+
+// paymentConfirmationTimeout bounds how long ConfirmERC20Payment's caller
+// waits for a payment to confirm. It runs synchronously inside the
+// processTxHash HTTP handler path, so a bad tx hash or a stalled RPC must
+// not hang that request forever; a few confirmation-poll cycles is plenty
+// for a payment that's actually going to land.
+const paymentConfirmationTimeout = 5 * time.Minute
+
+// ConfirmERC20Payment starts a LogWatcher for the given payment, broadcasts
+// its payment_confirmed/payment_reverted message to every connected
+// WebSocket client, and blocks until that happens (or ctx is done). It's
+// meant to be called right after a buyer submits their payment transaction
+// hash, gating the NFT mint/transfer finalize step on an actual, reorg-safe
+// on-chain payment instead of finalizing unconditionally. Callers should
+// bound ctx with a timeout (see paymentConfirmationTimeout) since this
+// blocks until the payment watcher reports a result or ctx is done.
+func (c *Client) ConfirmERC20Payment(ctx context.Context, params PaymentWatchParams) error {
+	watcher := NewLogWatcher(EthereumRPC)
+	msgs, err := watcher.WatchPayment(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to start payment watcher: %w", err)
+	}
+
+	msg, ok := <-msgs
+	if !ok {
+		return fmt.Errorf("payment watcher closed without a result for tx %s", params.TxHash)
+	}
+	c.broadcast <- msg
+	if msg.Type != "payment_confirmed" {
+		return fmt.Errorf("payment not confirmed for tx %s", params.TxHash)
+	}
+	return nil
+}
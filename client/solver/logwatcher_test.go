@@ -0,0 +1,72 @@
+package solver
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func transferLog(token, from, to common.Address, value *big.Int) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics: []common.Hash{
+			transferEventTopic,
+			common.BytesToHash(common.LeftPadBytes(from.Bytes(), 32)),
+			common.BytesToHash(common.LeftPadBytes(to.Bytes(), 32)),
+		},
+		Data: common.LeftPadBytes(value.Bytes(), 32),
+	}
+}
+
+func TestFindTransferLogIgnoresUnrelatedTransferToSameSeller(t *testing.T) {
+	token := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	seller := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	buyer := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	other := common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	logs := []*types.Log{
+		// A larger, unrelated transfer from a different sender to the same
+		// seller must not be mistaken for the buyer's payment.
+		transferLog(token, other, seller, big.NewInt(1_000_000)),
+		transferLog(token, buyer, seller, big.NewInt(100)),
+	}
+
+	got, err := findTransferLog(logs, token, buyer, seller, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("findTransferLog() error = %v", err)
+	}
+	if common.BytesToAddress(got.Topics[1].Bytes()) != buyer {
+		t.Fatalf("matched log from %s, want buyer %s", common.BytesToAddress(got.Topics[1].Bytes()), buyer)
+	}
+}
+
+func TestFindTransferLogErrorsWhenNoMatchingTransferExists(t *testing.T) {
+	token := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	seller := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	buyer := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	other := common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	logs := []*types.Log{
+		transferLog(token, other, seller, big.NewInt(1_000_000)),
+	}
+
+	if _, err := findTransferLog(logs, token, buyer, seller, big.NewInt(1)); err == nil {
+		t.Fatal("expected an error when no Transfer(buyer -> seller) log is present")
+	}
+}
+
+func TestFindTransferLogRejectsBelowMinAmount(t *testing.T) {
+	token := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	seller := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	buyer := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	logs := []*types.Log{
+		transferLog(token, buyer, seller, big.NewInt(50)),
+	}
+
+	if _, err := findTransferLog(logs, token, buyer, seller, big.NewInt(100)); err == nil {
+		t.Fatal("expected an error when the matching transfer is below MinAmount")
+	}
+}
@@ -0,0 +1,69 @@
+// Package nftid implements a Cosmos SDK ADR-043-style NFT identity scheme:
+// a class_id and an id, each validated independently, combined into a
+// canonical "{class_id}/{id}" primary key. This gives callers a stable,
+// portable NFT identifier instead of accepting arbitrary strings.
+package nftid
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// segmentPattern is the shape required of class_id: an alphabetic first
+// character followed by 2-100 alphanumerics, '/', ':' or '-'.
+var segmentPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9/:-]{2,100}$`)
+
+// idSegmentPattern is the shape required of id: the same as segmentPattern
+// but without '/', since ParseCanonical splits a canonical identifier on
+// the last '/' and an id containing one would break that round trip.
+var idSegmentPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9:-]{2,100}$`)
+
+// Validate reports an error if segment doesn't match the class_id shape
+// required by ADR-043.
+func Validate(segment string) error {
+	if !segmentPattern.MatchString(segment) {
+		return fmt.Errorf("invalid nft identifier segment %q: must match %s", segment, segmentPattern.String())
+	}
+	return nil
+}
+
+// validateID reports an error if id doesn't match the id shape required by
+// ADR-043. Unlike Validate, it rejects '/' so Format/ParseCanonical stay a
+// round trip.
+func validateID(id string) error {
+	if !idSegmentPattern.MatchString(id) {
+		return fmt.Errorf("invalid nft identifier segment %q: must match %s", id, idSegmentPattern.String())
+	}
+	return nil
+}
+
+// Format validates classID and id and joins them into their canonical
+// "{class_id}/{id}" primary key.
+func Format(classID, id string) (string, error) {
+	if err := Validate(classID); err != nil {
+		return "", fmt.Errorf("invalid class_id: %w", err)
+	}
+	if err := validateID(id); err != nil {
+		return "", fmt.Errorf("invalid id: %w", err)
+	}
+	return classID + "/" + id, nil
+}
+
+// ParseCanonical splits a canonical "{class_id}/{id}" identifier produced by
+// Format back into its class_id and id, validating both. It splits on the
+// last '/', so id itself must not contain one even though class_id may.
+func ParseCanonical(canonical string) (classID, id string, err error) {
+	idx := strings.LastIndex(canonical, "/")
+	if idx < 0 {
+		return "", "", fmt.Errorf("invalid canonical nft id %q: expected {class_id}/{id}", canonical)
+	}
+	classID, id = canonical[:idx], canonical[idx+1:]
+	if err := Validate(classID); err != nil {
+		return "", "", fmt.Errorf("invalid class_id: %w", err)
+	}
+	if err := validateID(id); err != nil {
+		return "", "", fmt.Errorf("invalid id: %w", err)
+	}
+	return classID, id, nil
+}
@@ -0,0 +1,63 @@
+package nftid
+
+import "testing"
+
+func TestValidateRejectsInputsNotMatchingTheADR043Shape(t *testing.T) {
+	cases := []struct {
+		name    string
+		segment string
+		wantErr bool
+	}{
+		{"valid", "gaia-nft", false},
+		{"valid with slash and colon", "gaia/nft:01", false},
+		{"too short", "ab", true},
+		{"leading digit", "1gaia", true},
+		{"disallowed char", "gaia_nft", true},
+		{"empty", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.segment)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tc.segment, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatAndParseCanonicalRoundTrip(t *testing.T) {
+	canonical, err := Format("gaia-collection", "token-01")
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if canonical != "gaia-collection/token-01" {
+		t.Errorf("Format() = %q, want %q", canonical, "gaia-collection/token-01")
+	}
+
+	classID, id, err := ParseCanonical(canonical)
+	if err != nil {
+		t.Fatalf("ParseCanonical() error = %v", err)
+	}
+	if classID != "gaia-collection" || id != "token-01" {
+		t.Errorf("ParseCanonical() = (%q, %q), want (%q, %q)", classID, id, "gaia-collection", "token-01")
+	}
+}
+
+func TestFormatRejectsInvalidSegment(t *testing.T) {
+	if _, err := Format("1bad", "token-01"); err == nil {
+		t.Error("expected Format() to reject an invalid class_id")
+	}
+}
+
+func TestFormatRejectsSlashInID(t *testing.T) {
+	if _, err := Format("gaia-collection", "id/with/slash"); err == nil {
+		t.Error("expected Format() to reject an id containing '/', since it would break the ParseCanonical round trip")
+	}
+}
+
+func TestParseCanonicalRejectsMissingSeparator(t *testing.T) {
+	if _, _, err := ParseCanonical("no-separator-here"); err == nil {
+		t.Error("expected ParseCanonical() to reject an identifier without a separator")
+	}
+}
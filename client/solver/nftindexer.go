@@ -0,0 +1,213 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// NFTMetadata is the standard ERC-721 JSON metadata schema resolved from a
+// token's tokenURI.
+type NFTMetadata struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+}
+
+// OwnedNFT merges one NFT held by an owner regardless of which chain it
+// lives on, so a wallet UI can render a unified inventory.
+type OwnedNFT struct {
+	Chain    string      `json:"chain"`
+	ClassId  string      `json:"classId"`
+	TokenId  string      `json:"tokenId"`
+	Metadata NFTMetadata `json:"metadata"`
+}
+
+// GetNFTsOfOwner enumerates every NFT held by owner, merging Linera-side
+// listings (via the existing nfts query) with Ethereum ERC-721 holdings
+// across classIds. This mirrors the Cosmos x/nft "NFTsOfOwner" query, but
+// across chains instead of within one.
+func (c *Client) GetNFTsOfOwner(owner string, classIds []string) ([]OwnedNFT, error) {
+	owned, err := c.getLineraNFTsOfOwner(owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate Linera NFTs: %w", err)
+	}
+
+	ethOwned, err := c.getEthereumNFTsOfOwner(owner, classIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate Ethereum NFTs: %w", err)
+	}
+
+	return append(owned, ethOwned...), nil
+}
+
+// getLineraNFTsOfOwner filters the existing nfts query down to owner, since
+// the GraphQL schema has no owner-scoped query of its own yet.
+func (c *Client) getLineraNFTsOfOwner(owner string) ([]OwnedNFT, error) {
+	nfts, err := c.GetAllNFTs()
+	if err != nil {
+		return nil, err
+	}
+	return filterNFTsByOwner(nfts, owner), nil
+}
+
+// filterNFTsByOwner is split out from getLineraNFTsOfOwner so the filtering
+// logic can be tested without a live nonFungibleURL.
+func filterNFTsByOwner(nfts map[string]NFT, owner string) []OwnedNFT {
+	var owned []OwnedNFT
+	for _, nft := range nfts {
+		if nft.Owner != owner {
+			continue
+		}
+		owned = append(owned, OwnedNFT{
+			Chain:   "linera",
+			ClassId: nft.Token,
+			TokenId: nft.TokenId,
+			Metadata: NFTMetadata{
+				Name:        nft.Name,
+				Description: nft.Description,
+			},
+		})
+	}
+	return owned
+}
+
+// getEthereumNFTsOfOwner walks classIds (ERC-721 contract addresses),
+// calling the standard balanceOf/tokenOfOwnerByIndex/tokenURI selectors via
+// bind.NewBoundContract, the same way ExecuteNFTContractTransaction already
+// does. A classId that isn't ERC-721-enumerable (call fails) is logged and
+// skipped rather than failing the whole lookup.
+func (c *Client) getEthereumNFTsOfOwner(owner string, classIds []string) ([]OwnedNFT, error) {
+	if len(classIds) == 0 {
+		return nil, nil
+	}
+
+	client, err := ethclient.Dial(EthereumRPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	ownerAddress := common.HexToAddress(owner)
+
+	var owned []OwnedNFT
+	for _, classId := range classIds {
+		contract := bind.NewBoundContract(common.HexToAddress(classId), marketplaceABI, client, client, client)
+
+		var balanceOut []interface{}
+		if err := contract.Call(&bind.CallOpts{}, &balanceOut, "balanceOf", ownerAddress); err != nil {
+			Logger.Printf("failed to read balanceOf for class %s: %v", classId, err)
+			continue
+		}
+		balance := balanceOut[0].(*big.Int)
+
+		for i := int64(0); i < balance.Int64(); i++ {
+			var tokenOut []interface{}
+			if err := contract.Call(&bind.CallOpts{}, &tokenOut, "tokenOfOwnerByIndex", ownerAddress, big.NewInt(i)); err != nil {
+				Logger.Printf("failed to read tokenOfOwnerByIndex %d for class %s: %v", i, classId, err)
+				continue
+			}
+			tokenId := tokenOut[0].(*big.Int)
+
+			owned = append(owned, OwnedNFT{
+				Chain:    "ethereum",
+				ClassId:  classId,
+				TokenId:  tokenId.String(),
+				Metadata: resolveTokenMetadata(contract, tokenId),
+			})
+		}
+	}
+	return owned, nil
+}
+
+// resolveTokenMetadata fetches tokenId's tokenURI and follows it to resolve
+// the standard ERC-721 JSON metadata (name, description, image). Failures
+// are logged and return zero-value metadata rather than failing the whole
+// enumeration, since one bad token shouldn't hide the rest of the wallet.
+func resolveTokenMetadata(contract *bind.BoundContract, tokenId *big.Int) NFTMetadata {
+	var uriOut []interface{}
+	if err := contract.Call(&bind.CallOpts{}, &uriOut, "tokenURI", tokenId); err != nil {
+		Logger.Printf("failed to read tokenURI for token %s: %v", tokenId, err)
+		return NFTMetadata{}
+	}
+	uri, _ := uriOut[0].(string)
+	if uri == "" {
+		return NFTMetadata{}
+	}
+	uri = strings.Replace(uri, "ipfs://", "https://ipfs.io/ipfs/", 1)
+
+	resp, err := http.Get(uri)
+	if err != nil {
+		Logger.Printf("failed to fetch tokenURI %s: %v", uri, err)
+		return NFTMetadata{}
+	}
+	defer resp.Body.Close()
+
+	var metadata NFTMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		Logger.Printf("failed to parse tokenURI metadata %s: %v", uri, err)
+		return NFTMetadata{}
+	}
+	return metadata
+}
+
+// GetERC1155BalancesOfOwner batch-queries balanceOfBatch for owner across
+// (classId, tokenId) pairs. ERC-1155 has no enumerable extension, so unlike
+// GetNFTsOfOwner the caller must already know which token ids to check
+// (e.g. from the marketplace listing being priced).
+func (c *Client) GetERC1155BalancesOfOwner(owner string, classIds, tokenIds []string) (map[string]*big.Int, error) {
+	if len(classIds) != len(tokenIds) {
+		return nil, fmt.Errorf("classIds and tokenIds must be the same length, got %d and %d", len(classIds), len(tokenIds))
+	}
+	if len(classIds) == 0 {
+		return nil, nil
+	}
+
+	client, err := ethclient.Dial(EthereumRPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+	}
+	defer client.Close()
+
+	ownerAddress := common.HexToAddress(owner)
+
+	// balanceOfBatch is itself per-contract, so group token ids by class
+	// before issuing one batched call per class.
+	byClass := make(map[string][]string)
+	for i, classId := range classIds {
+		byClass[classId] = append(byClass[classId], tokenIds[i])
+	}
+
+	balances := make(map[string]*big.Int, len(classIds))
+	for classId, ids := range byClass {
+		contract := bind.NewBoundContract(common.HexToAddress(classId), marketplaceABI, client, client, client)
+
+		owners := make([]common.Address, len(ids))
+		idInts := make([]*big.Int, len(ids))
+		for i, id := range ids {
+			owners[i] = ownerAddress
+			idInt, ok := new(big.Int).SetString(id, 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid token id %q for class %s", id, classId)
+			}
+			idInts[i] = idInt
+		}
+
+		var out []interface{}
+		if err := contract.Call(&bind.CallOpts{}, &out, "balanceOfBatch", owners, idInts); err != nil {
+			return nil, fmt.Errorf("failed to read balanceOfBatch for class %s: %w", classId, err)
+		}
+		amounts := out[0].([]*big.Int)
+		for i, id := range ids {
+			balances[classId+":"+id] = amounts[i]
+		}
+	}
+
+	return balances, nil
+}
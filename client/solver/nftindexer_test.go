@@ -0,0 +1,32 @@
+package solver
+
+import "testing"
+
+func TestFilterNFTsByOwner(t *testing.T) {
+	nfts := map[string]NFT{
+		"1": {TokenId: "1", Owner: "0xabc", Token: "TOK", Name: "First"},
+		"2": {TokenId: "2", Owner: "0xdef", Token: "TOK", Name: "Second"},
+		"3": {TokenId: "3", Owner: "0xabc", Token: "TOK", Name: "Third"},
+	}
+
+	owned := filterNFTsByOwner(nfts, "0xabc")
+
+	if len(owned) != 2 {
+		t.Fatalf("filterNFTsByOwner() returned %d entries, want 2", len(owned))
+	}
+	for _, nft := range owned {
+		if nft.Chain != "linera" {
+			t.Errorf("OwnedNFT.Chain = %q, want %q", nft.Chain, "linera")
+		}
+	}
+}
+
+func TestGetERC1155BalancesOfOwnerRejectsMismatchedLengths(t *testing.T) {
+	c := NewClient("", "", "")
+	defer c.Close()
+
+	_, err := c.GetERC1155BalancesOfOwner("0xabc", []string{"0x1"}, nil)
+	if err == nil {
+		t.Fatal("expected error for mismatched classIds/tokenIds lengths")
+	}
+}
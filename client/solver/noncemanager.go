@@ -0,0 +1,76 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// NonceManager reserves nonces atomically per (chain, fromAddress) so
+// concurrent swaps for the same pool address don't race on the result of
+// PendingNonceAt. It seeds its counter from the chain on first use and
+// increments locally after that; call Resync to recover from drift (e.g.
+// after a submission failure leaves the local counter ahead of the chain).
+type NonceManager struct {
+	mu     sync.Mutex
+	nonces map[string]uint64
+}
+
+func NewNonceManager() *NonceManager {
+	return &NonceManager{nonces: make(map[string]uint64)}
+}
+
+// defaultNonceManager is shared by every nonce-assigning code path
+// (Client.prepareEthereumTransaction, EthBackend.PrepareTransaction,
+// ERC20Client.PrepareTransfer) so they don't each race against the chain
+// independently.
+var defaultNonceManager = NewNonceManager()
+
+func nonceKey(chain, fromAddress string) string {
+	return chain + ":" + fromAddress
+}
+
+// Reserve returns the next nonce to use for (chain, fromAddress).
+func (n *NonceManager) Reserve(ctx context.Context, chain, fromAddress string) (uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	key := nonceKey(chain, fromAddress)
+	if _, seeded := n.nonces[key]; !seeded {
+		pending, err := fetchPendingNonce(ctx, chain, fromAddress)
+		if err != nil {
+			return 0, err
+		}
+		n.nonces[key] = pending
+	}
+
+	nonce := n.nonces[key]
+	n.nonces[key]++
+	return nonce, nil
+}
+
+// Resync discards the cached nonce for (chain, fromAddress), so the next
+// Reserve re-seeds from the chain's pending nonce instead of continuing a
+// counter that may have drifted (e.g. after a failed submission).
+func (n *NonceManager) Resync(chain, fromAddress string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.nonces, nonceKey(chain, fromAddress))
+}
+
+func fetchPendingNonce(ctx context.Context, chain, fromAddress string) (uint64, error) {
+	switch chain {
+	case "ethereum":
+		client, err := ethclient.Dial(EthereumRPC)
+		if err != nil {
+			return 0, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+		}
+		defer client.Close()
+		return client.PendingNonceAt(ctx, common.HexToAddress(fromAddress))
+	default:
+		return 0, fmt.Errorf("nonce management not supported for chain %s", chain)
+	}
+}
@@ -0,0 +1,343 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// paymentChannelABIJson is the Solidity ABI of the payment-channel contract
+// backing PaymentChannel: a buyer opens a channel with a deposit and
+// redeems it later for the highest-nonce off-chain ticket, instead of
+// requiring one executeSale transaction per sale from the same seller.
+const paymentChannelABIJson = `[
+	{
+		"inputs": [
+			{ "internalType": "address", "name": "seller", "type": "address" }
+		],
+		"name": "createChannel",
+		"outputs": [
+			{ "internalType": "bytes32", "name": "", "type": "bytes32" }
+		],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{ "internalType": "bytes32", "name": "channelID", "type": "bytes32" },
+			{ "internalType": "uint256", "name": "cumulativeAmount", "type": "uint256" },
+			{ "internalType": "bytes", "name": "signature", "type": "bytes" }
+		],
+		"name": "close",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+var paymentChannelABI abi.ABI
+
+func init() {
+	var err error
+	paymentChannelABI, err = abi.JSON(strings.NewReader(paymentChannelABIJson))
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse payment channel ABI: %v", err))
+	}
+}
+
+// Ticket is a signed, off-chain promise that the channel's buyer owes
+// CumulativeAmount to the seller so far. Tickets for the same channel carry
+// strictly increasing CumulativeAmount; only the highest one needs to be
+// redeemed on-chain, via Close.
+type Ticket struct {
+	ChannelID        [32]byte
+	CumulativeAmount *big.Int
+	Signature        []byte
+}
+
+// channelState is the client's local view of one open payment channel.
+type channelState struct {
+	seller     common.Address
+	deposit    *big.Int
+	lastTicket *Ticket
+}
+
+// PaymentChannel lets a buyer settle repeated sales from the same seller
+// with a single on-chain deposit plus signed off-chain tickets, instead of
+// one executeSale transaction per sale. OpenChannel deposits funds and
+// returns a channel ID; SignTicket raises the cumulative amount owed and
+// hands back a ticket the seller can verify immediately with VerifyTicket
+// and redeem later with Close.
+type PaymentChannel struct {
+	chainKey string
+	entry    *evmChainEntry
+	bound    *bind.BoundContract
+
+	mu       sync.Mutex
+	channels map[[32]byte]*channelState
+}
+
+// NewPaymentChannel returns a PaymentChannel backed by the payment-channel
+// contract at contractAddr on the chain registered in the EVM chain
+// registry under chainKey.
+func NewPaymentChannel(chainKey string, entry *evmChainEntry, contractAddr common.Address) *PaymentChannel {
+	return &PaymentChannel{
+		chainKey: chainKey,
+		entry:    entry,
+		bound:    bind.NewBoundContract(contractAddr, paymentChannelABI, entry.client, entry.client, entry.client),
+		channels: make(map[[32]byte]*channelState),
+	}
+}
+
+// OpenChannel deposits deposit wei with seller and returns the resulting
+// channel ID, derived from the buyer, seller and the opening transaction's
+// hash so it's unique per (buyer, seller, deposit) without needing to
+// decode a contract event.
+func (p *PaymentChannel) OpenChannel(seller common.Address, deposit *big.Int) ([32]byte, error) {
+	ctx := context.Background()
+	auth, err := defaultTxManager.PrepareAuth(ctx, p.chainKey, p.entry, chainKeys.EthereumKey)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to prepare transaction: %w", err)
+	}
+	auth.Value = deposit
+
+	tx, err := defaultTxManager.Submit(ctx, p.entry.client, auth, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return p.bound.Transact(auth, "createChannel", seller)
+	})
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to create channel: %w", err)
+	}
+	if _, err := defaultTxManager.WaitMinedWithConfirmations(ctx, p.entry.client, tx, ethConfirmationDepth); err != nil {
+		return [32]byte{}, fmt.Errorf("failed to wait for channel open: %w", err)
+	}
+
+	buyer := crypto.PubkeyToAddress(chainKeys.EthereumKey.PublicKey)
+	channelID := crypto.Keccak256Hash(buyer.Bytes(), seller.Bytes(), tx.Hash().Bytes())
+
+	var id [32]byte
+	copy(id[:], channelID.Bytes())
+
+	p.mu.Lock()
+	p.channels[id] = &channelState{seller: seller, deposit: deposit}
+	p.mu.Unlock()
+
+	return id, nil
+}
+
+// ticketHash returns keccak256(channelID || amount), the digest SignTicket
+// signs and VerifyTicket checks against.
+func ticketHash(channelID [32]byte, cumulativeAmount *big.Int) []byte {
+	amountBytes := common.LeftPadBytes(cumulativeAmount.Bytes(), 32)
+	return crypto.Keccak256(channelID[:], amountBytes)
+}
+
+// SignTicket signs a ticket promising cumulativeAmount to the channel's
+// seller, using chainKeys.EthereumKey, and records it as the channel's
+// latest ticket.
+func (p *PaymentChannel) SignTicket(channelID [32]byte, cumulativeAmount *big.Int) (*Ticket, error) {
+	if chainKeys == nil || chainKeys.EthereumKey == nil {
+		return nil, fmt.Errorf("ethereum private key not initialized")
+	}
+
+	p.mu.Lock()
+	state, ok := p.channels[channelID]
+	p.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown channel: %x", channelID)
+	}
+	if state.lastTicket != nil && cumulativeAmount.Cmp(state.lastTicket.CumulativeAmount) <= 0 {
+		return nil, fmt.Errorf("cumulative amount must increase: %s <= %s", cumulativeAmount, state.lastTicket.CumulativeAmount)
+	}
+
+	sig, err := crypto.Sign(ticketHash(channelID, cumulativeAmount), chainKeys.EthereumKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign ticket: %w", err)
+	}
+
+	ticket := &Ticket{ChannelID: channelID, CumulativeAmount: cumulativeAmount, Signature: sig}
+
+	p.mu.Lock()
+	state.lastTicket = ticket
+	p.mu.Unlock()
+
+	return ticket, nil
+}
+
+// LastTicket returns the most recently signed ticket for channelID, or nil
+// if none has been signed yet.
+func (p *PaymentChannel) LastTicket(channelID [32]byte) *Ticket {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state, ok := p.channels[channelID]
+	if !ok {
+		return nil
+	}
+	return state.lastTicket
+}
+
+// VerifyTicket reports whether ticket was signed by signer over
+// keccak256(channelID || amount).
+func VerifyTicket(ticket *Ticket, signer common.Address) bool {
+	pubkey, err := crypto.SigToPub(ticketHash(ticket.ChannelID, ticket.CumulativeAmount), ticket.Signature)
+	if err != nil {
+		return false
+	}
+	return crypto.PubkeyToAddress(*pubkey) == signer
+}
+
+// openPaymentChannel is a Client's bookkeeping for one channel it has
+// opened: the channel ID plus the cumulative amount promised so far, so
+// each new ticket strictly increases on the last one.
+type openPaymentChannel struct {
+	pc         *PaymentChannel
+	channelID  [32]byte
+	cumulative *big.Int
+}
+
+// OpenPaymentChannel opens a payment channel to seller on chainKey,
+// depositing deposit wei against the payment-channel contract at
+// contractAddr, so repeated sales from seller can settle through
+// settleThroughChannel instead of one executeSale transaction each. It's a
+// no-op returning the existing channel ID if one is already open for
+// seller.
+func (c *Client) OpenPaymentChannel(chainKey string, contractAddr, seller common.Address, deposit *big.Int) ([32]byte, error) {
+	c.paymentChannelsLock.Lock()
+	if existing, ok := c.paymentChannels[seller.Hex()]; ok {
+		c.paymentChannelsLock.Unlock()
+		return existing.channelID, nil
+	}
+
+	entry, ok := DefaultEVMChainRegistry.Get(chainKey)
+	if !ok {
+		c.paymentChannelsLock.Unlock()
+		return [32]byte{}, fmt.Errorf("unregistered chain: %s", chainKey)
+	}
+
+	// Reserve the seller's slot before the blocking on-chain OpenChannel
+	// call below, then release the lock for its duration: holding
+	// paymentChannelsLock across a multi-confirmation wait would stall
+	// settleThroughChannel/ClosePaymentChannel for every other seller
+	// until this one channel finishes opening.
+	oc := &openPaymentChannel{pc: NewPaymentChannel(chainKey, entry, contractAddr), cumulative: big.NewInt(0)}
+	c.paymentChannels[seller.Hex()] = oc
+	c.paymentChannelsLock.Unlock()
+
+	channelID, err := oc.pc.OpenChannel(seller, deposit)
+	if err != nil {
+		c.paymentChannelsLock.Lock()
+		delete(c.paymentChannels, seller.Hex())
+		c.paymentChannelsLock.Unlock()
+		return [32]byte{}, err
+	}
+
+	c.paymentChannelsLock.Lock()
+	oc.channelID = channelID
+	c.paymentChannelsLock.Unlock()
+
+	return channelID, nil
+}
+
+// settleThroughChannel signs a ticket raising the cumulative amount owed to
+// seller on an already-open payment channel and broadcasts
+// nft_ticket_signed, instead of submitting an executeSale transaction. ok
+// is false if no channel is open for seller, so the caller should fall back
+// to ExecuteNFTContractTransaction.
+func (c *Client) settleThroughChannel(seller string, amountWei *big.Int) (ticket *Ticket, ok bool, err error) {
+	c.paymentChannelsLock.Lock()
+	oc, found := c.paymentChannels[seller]
+	var newCumulative *big.Int
+	if found {
+		newCumulative = new(big.Int).Add(oc.cumulative, amountWei)
+	}
+	c.paymentChannelsLock.Unlock()
+	if !found {
+		return nil, false, nil
+	}
+
+	// Only commit newCumulative once SignTicket has actually signed it:
+	// bumping oc.cumulative first and leaving it bumped on error would
+	// permanently overstate what's owed on the channel.
+	ticket, err = oc.pc.SignTicket(oc.channelID, newCumulative)
+	if err != nil {
+		return nil, true, err
+	}
+
+	c.paymentChannelsLock.Lock()
+	oc.cumulative = newCumulative
+	c.paymentChannelsLock.Unlock()
+
+	c.broadcast <- WSMessage{
+		Type: "nft_ticket_signed",
+		Data: map[string]interface{}{
+			"channelId":        fmt.Sprintf("0x%x", oc.channelID),
+			"cumulativeAmount": ticket.CumulativeAmount.String(),
+		},
+	}
+	return ticket, true, nil
+}
+
+// ClosePaymentChannel redeems the channel's last signed ticket on-chain and
+// broadcasts nft_channel_closed.
+func (c *Client) ClosePaymentChannel(seller string) (string, error) {
+	c.paymentChannelsLock.Lock()
+	oc, ok := c.paymentChannels[seller]
+	if ok {
+		delete(c.paymentChannels, seller)
+	}
+	c.paymentChannelsLock.Unlock()
+	if !ok {
+		return "", fmt.Errorf("no open payment channel for %s", seller)
+	}
+	lastTicket := oc.pc.LastTicket(oc.channelID)
+	if lastTicket == nil {
+		return "", fmt.Errorf("no ticket to redeem for %s", seller)
+	}
+
+	txHash, err := oc.pc.Close(oc.channelID, lastTicket)
+	if err != nil {
+		return "", err
+	}
+
+	c.broadcast <- WSMessage{
+		Type: "nft_channel_closed",
+		Data: map[string]interface{}{
+			"channelId": fmt.Sprintf("0x%x", oc.channelID),
+			"txHash":    txHash,
+		},
+	}
+	return txHash, nil
+}
+
+// Close redeems lastTicket on-chain, paying the channel's seller its
+// cumulative amount and returning the remaining deposit to the buyer.
+func (p *PaymentChannel) Close(channelID [32]byte, lastTicket *Ticket) (string, error) {
+	ctx := context.Background()
+	auth, err := defaultTxManager.PrepareAuth(ctx, p.chainKey, p.entry, chainKeys.EthereumKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare transaction: %w", err)
+	}
+
+	tx, err := defaultTxManager.Submit(ctx, p.entry.client, auth, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return p.bound.Transact(auth, "close", channelID, lastTicket.CumulativeAmount, lastTicket.Signature)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to close channel: %w", err)
+	}
+	if _, err := defaultTxManager.WaitMinedWithConfirmations(ctx, p.entry.client, tx, ethConfirmationDepth); err != nil {
+		return "", fmt.Errorf("failed to wait for channel close: %w", err)
+	}
+
+	p.mu.Lock()
+	delete(p.channels, channelID)
+	p.mu.Unlock()
+
+	return tx.Hash().Hex(), nil
+}
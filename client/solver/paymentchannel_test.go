@@ -0,0 +1,69 @@
+package solver
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerifyTicketAcceptsMatchingSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	var channelID [32]byte
+	channelID[0] = 1
+	amount := big.NewInt(100)
+
+	sig, err := crypto.Sign(ticketHash(channelID, amount), key)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	ticket := &Ticket{ChannelID: channelID, CumulativeAmount: amount, Signature: sig}
+
+	if !VerifyTicket(ticket, signer) {
+		t.Fatal("expected ticket signed by signer to verify")
+	}
+}
+
+func TestVerifyTicketRejectsWrongSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+
+	var channelID [32]byte
+	amount := big.NewInt(50)
+	sig, _ := crypto.Sign(ticketHash(channelID, amount), key)
+	ticket := &Ticket{ChannelID: channelID, CumulativeAmount: amount, Signature: sig}
+
+	if VerifyTicket(ticket, crypto.PubkeyToAddress(other.PublicKey)) {
+		t.Fatal("expected ticket to not verify against a different signer")
+	}
+}
+
+// TestSettleThroughChannelDoesNotBumpCumulativeOnSignFailure exercises
+// settleThroughChannel's client-level bookkeeping, not the on-chain
+// signature path: chainKeys is left uninitialized (its package default in
+// this test binary), so PaymentChannel.SignTicket always fails, and
+// oc.cumulative must be left exactly where it started rather than
+// permanently overstating what's owed on the channel.
+func TestSettleThroughChannelDoesNotBumpCumulativeOnSignFailure(t *testing.T) {
+	seller := "0xseller"
+	oc := &openPaymentChannel{
+		pc:         &PaymentChannel{channels: map[[32]byte]*channelState{}},
+		cumulative: big.NewInt(100),
+	}
+
+	c := NewClient("", "", "")
+	c.paymentChannels[seller] = oc
+
+	if _, _, err := c.settleThroughChannel(seller, big.NewInt(10)); err == nil {
+		t.Fatal("expected settleThroughChannel to fail with chainKeys uninitialized")
+	}
+
+	if oc.cumulative.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("cumulative = %s, want unchanged 100 after a failed SignTicket", oc.cumulative)
+	}
+}
@@ -0,0 +1,231 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gagliardetto/solana-go"
+)
+
+// Signer abstracts where a transaction's private key material actually
+// lives, mirroring go-ethereum's bind.TransactOpts.Signer pattern. This
+// lets the solver run against a KMS-held key or a remote signing service
+// instead of keeping raw private keys in process memory.
+type Signer interface {
+	SignEthereum(ctx context.Context, signer types.Signer, tx *types.Transaction) (*types.Transaction, error)
+	SignSolana(ctx context.Context, msg []byte, pubkey solana.PublicKey) ([]byte, error)
+}
+
+// LocalSigner signs with the seed-phrase-derived keys from InitKeys. It's
+// the default, matching the solver's original in-process-key behavior.
+type LocalSigner struct{}
+
+func (LocalSigner) SignEthereum(ctx context.Context, signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	if chainKeys == nil || chainKeys.EthereumKey == nil {
+		return nil, fmt.Errorf("ethereum private key not initialized")
+	}
+	return types.SignTx(tx, signer, chainKeys.EthereumKey)
+}
+
+func (LocalSigner) SignSolana(ctx context.Context, msg []byte, pubkey solana.PublicKey) ([]byte, error) {
+	if chainKeys == nil || chainKeys.SolanaKey == nil {
+		return nil, fmt.Errorf("solana private key not initialized")
+	}
+	if !chainKeys.SolanaKey.PublicKey().Equals(pubkey) {
+		return nil, fmt.Errorf("no local key for %s", pubkey)
+	}
+	sig, err := chainKeys.SolanaKey.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return sig[:], nil
+}
+
+// AWSKMSSigner signs Ethereum transactions with a SECP256K1 key held in AWS
+// KMS, never bringing the private key into this process. KMS returns an ASN.1
+// DER (r, s) signature without a recovery id, so the Ethereum v value is
+// reconstructed by trial-recovering against the expected address.
+type AWSKMSSigner struct {
+	// KeyID is the KMS key identifier (ARN or alias) holding the
+	// SECP256K1 signing key.
+	KeyID string
+	// Sign calls kms:Sign with MessageType DIGEST and SigningAlgorithm
+	// ECDSA_SHA_256, returning the DER-encoded (r, s) signature. Callers
+	// inject this instead of taking an AWS SDK dependency directly here.
+	Sign func(ctx context.Context, keyID string, digest [32]byte) (derSignature []byte, err error)
+	// Address is the Ethereum address corresponding to KeyID's public key.
+	Address [20]byte
+}
+
+func (s *AWSKMSSigner) SignEthereum(ctx context.Context, signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	if s.Sign == nil {
+		return nil, fmt.Errorf("AWSKMSSigner: Sign callback not configured")
+	}
+
+	digest := signer.Hash(tx)
+	der, err := s.Sign(ctx, s.KeyID, digest)
+	if err != nil {
+		return nil, fmt.Errorf("KMS signing failed: %w", err)
+	}
+
+	r, sVal, err := decodeDERSignature(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode KMS signature: %w", err)
+	}
+	sig, err := recoverRecoveryID(digest, r, sVal, s.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover recovery id: %w", err)
+	}
+
+	return tx.WithSignature(signer, sig)
+}
+
+func (s *AWSKMSSigner) SignSolana(ctx context.Context, msg []byte, pubkey solana.PublicKey) ([]byte, error) {
+	return nil, fmt.Errorf("AWSKMSSigner does not support Solana (Ed25519) keys")
+}
+
+// RemoteSigner delegates signing to an external service by POSTing the
+// canonical sighash and receiving back a raw signature, keeping the key
+// entirely out of this process.
+type RemoteSigner struct {
+	URL  string
+	http *http.Client
+}
+
+func NewRemoteSigner(url string) *RemoteSigner {
+	return &RemoteSigner{URL: url, http: &http.Client{}}
+}
+
+type remoteSignRequest struct {
+	Chain string `json:"chain"`
+	Hash  string `json:"hash"`
+}
+
+type remoteSignResponse struct {
+	Signature string `json:"signature"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (s *RemoteSigner) postSighash(ctx context.Context, chain string, hash []byte) ([]byte, error) {
+	body, err := json.Marshal(remoteSignRequest{Chain: chain, Hash: "0x" + hex.EncodeToString(hash)})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result remoteSignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse remote signer response: %w", err)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("remote signer error: %s", result.Error)
+	}
+
+	return hexDecode(result.Signature)
+}
+
+func (s *RemoteSigner) SignEthereum(ctx context.Context, signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	digest := signer.Hash(tx)
+	sigBytes, err := s.postSighash(ctx, "ethereum", digest[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(sigBytes) != 65 {
+		return nil, fmt.Errorf("remote signer returned a %d-byte signature, want 65", len(sigBytes))
+	}
+	return tx.WithSignature(signer, sigBytes)
+}
+
+func (s *RemoteSigner) SignSolana(ctx context.Context, msg []byte, pubkey solana.PublicKey) ([]byte, error) {
+	return s.postSighash(ctx, "solana", msg)
+}
+
+func hexDecode(s string) ([]byte, error) {
+	if len(s) >= 2 && s[0] == '0' && s[1] == 'x' {
+		s = s[2:]
+	}
+	return hex.DecodeString(s)
+}
+
+// decodeDERSignature extracts (r, s) from a DER-encoded ECDSA signature as
+// returned by KMS's Sign API.
+func decodeDERSignature(der []byte) (r, s []byte, err error) {
+	// Minimal SEQUENCE { INTEGER r, INTEGER s } parser: KMS never returns
+	// anything else for ECDSA_SHA_256 over a SECP256K1 key.
+	if len(der) < 8 || der[0] != 0x30 {
+		return nil, nil, fmt.Errorf("not a DER SEQUENCE")
+	}
+	rest := der[2:]
+	if rest[0] != 0x02 {
+		return nil, nil, fmt.Errorf("expected INTEGER for r")
+	}
+	rLen := int(rest[1])
+	r = bytes.TrimLeft(rest[2:2+rLen], "\x00")
+	rest = rest[2+rLen:]
+	if rest[0] != 0x02 {
+		return nil, nil, fmt.Errorf("expected INTEGER for s")
+	}
+	sLen := int(rest[1])
+	s = bytes.TrimLeft(rest[2:2+sLen], "\x00")
+	return r, s, nil
+}
+
+// secp256k1HalfOrder is half the secp256k1 curve order, used to normalize a
+// signature's s value to the canonical low-S form Ethereum requires (EIP-2,
+// the homestead malleability fix). AWS KMS's ECDSA_SHA_256 signatures are
+// high-S roughly half the time; a node rejects a high-S signature as
+// non-canonical even though it recovers to the right address.
+var secp256k1HalfOrder = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// normalizeS returns s's canonical low-S form: if s > secp256k1HalfOrder,
+// it's replaced with N - s, s's other valid representation for the same
+// signature.
+func normalizeS(s []byte) []byte {
+	sBig := new(big.Int).SetBytes(s)
+	if sBig.Cmp(secp256k1HalfOrder) > 0 {
+		sBig.Sub(crypto.S256().Params().N, sBig)
+	}
+	return sBig.Bytes()
+}
+
+// recoverRecoveryID tries both possible recovery ids (0 and 1) against the
+// expected signer address, since KMS signatures don't carry Ethereum's v. s
+// is normalized to canonical low-S form first, so the signature this returns
+// is always one Ethereum will accept as non-malleable.
+func recoverRecoveryID(digest [32]byte, r, s []byte, expected [20]byte) ([]byte, error) {
+	s = normalizeS(s)
+
+	sig := make([]byte, 65)
+	copy(sig[32-len(r):32], r)
+	copy(sig[64-len(s):64], s)
+
+	for recID := byte(0); recID < 2; recID++ {
+		sig[64] = recID
+		pub, err := crypto.SigToPub(digest[:], sig)
+		if err != nil {
+			continue
+		}
+		if crypto.PubkeyToAddress(*pub) == expected {
+			return sig, nil
+		}
+	}
+	return nil, fmt.Errorf("could not recover a signature matching the expected address")
+}
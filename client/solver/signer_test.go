@@ -0,0 +1,158 @@
+package solver
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// encodeDERInteger DER-encodes b as a positive INTEGER, prepending a 0x00
+// pad byte when the high bit is set, mirroring what a KMS would return.
+func encodeDERInteger(b []byte) []byte {
+	b = bytes.TrimLeft(b, "\x00")
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return append([]byte{0x02, byte(len(b))}, b...)
+}
+
+// encodeDERSignature builds a minimal SEQUENCE { INTEGER r, INTEGER s },
+// the shape decodeDERSignature expects back from KMS.
+func encodeDERSignature(r, s []byte) []byte {
+	body := append(encodeDERInteger(r), encodeDERInteger(s)...)
+	return append([]byte{0x30, byte(len(body))}, body...)
+}
+
+func TestDecodeDERSignatureRoundTrips(t *testing.T) {
+	r := []byte{0x01, 0x02, 0x03}
+	s := []byte{0x80, 0x04, 0x05} // high bit set, so DER pads it with 0x00
+
+	der := encodeDERSignature(r, s)
+	gotR, gotS, err := decodeDERSignature(der)
+	if err != nil {
+		t.Fatalf("decodeDERSignature() error = %v", err)
+	}
+	if !bytes.Equal(gotR, r) {
+		t.Errorf("r = %x, want %x", gotR, r)
+	}
+	if !bytes.Equal(gotS, s) {
+		t.Errorf("s = %x, want %x", gotS, s)
+	}
+}
+
+func TestDecodeDERSignatureRejectsNonSequence(t *testing.T) {
+	if _, _, err := decodeDERSignature([]byte{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}); err == nil {
+		t.Error("expected a non-SEQUENCE input to be rejected")
+	}
+}
+
+func TestRecoverRecoveryIDFindsTheMatchingID(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	var digest [32]byte
+	copy(digest[:], crypto.Keccak256([]byte("test digest")))
+
+	sig, err := crypto.Sign(digest[:], key)
+	if err != nil {
+		t.Fatalf("crypto.Sign() error = %v", err)
+	}
+	r, s := sig[:32], sig[32:64]
+
+	got, err := recoverRecoveryID(digest, r, s, addr)
+	if err != nil {
+		t.Fatalf("recoverRecoveryID() error = %v", err)
+	}
+
+	pub, err := crypto.SigToPub(digest[:], got)
+	if err != nil {
+		t.Fatalf("crypto.SigToPub() error = %v", err)
+	}
+	if crypto.PubkeyToAddress(*pub) != addr {
+		t.Error("recoverRecoveryID returned a signature that doesn't recover to the expected address")
+	}
+}
+
+// TestRecoverRecoveryIDNormalizesHighS proves a high-S signature (as KMS's
+// ECDSA_SHA_256 returns roughly half the time) is normalized to canonical
+// low-S form rather than passed through as-is. crypto.Sign always returns
+// low-S, so s is flipped to its high-S counterpart (N - s) here to simulate
+// what a real KMS response looks like.
+func TestRecoverRecoveryIDNormalizesHighS(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	var digest [32]byte
+	copy(digest[:], crypto.Keccak256([]byte("test digest")))
+
+	sig, err := crypto.Sign(digest[:], key)
+	if err != nil {
+		t.Fatalf("crypto.Sign() error = %v", err)
+	}
+	r := sig[:32]
+
+	sBig := new(big.Int).SetBytes(sig[32:64])
+	if sBig.Cmp(secp256k1HalfOrder) > 0 {
+		t.Fatal("crypto.Sign() unexpectedly returned a high-S signature; can't construct the high-S case")
+	}
+	highS := new(big.Int).Sub(crypto.S256().Params().N, sBig).Bytes()
+
+	got, err := recoverRecoveryID(digest, r, highS, addr)
+	if err != nil {
+		t.Fatalf("recoverRecoveryID() error = %v", err)
+	}
+
+	gotS := new(big.Int).SetBytes(got[32:64])
+	if gotS.Cmp(secp256k1HalfOrder) > 0 {
+		t.Errorf("recoverRecoveryID returned a non-canonical high-S signature: s = %x", got[32:64])
+	}
+
+	pub, err := crypto.SigToPub(digest[:], got)
+	if err != nil {
+		t.Fatalf("crypto.SigToPub() error = %v", err)
+	}
+	if crypto.PubkeyToAddress(*pub) != addr {
+		t.Error("recoverRecoveryID returned a signature that doesn't recover to the expected address")
+	}
+}
+
+func TestRecoverRecoveryIDFailsForWrongAddress(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	var wrongKey *ecdsa.PrivateKey
+	for {
+		wrongKey, err = crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("GenerateKey() error = %v", err)
+		}
+		if crypto.PubkeyToAddress(wrongKey.PublicKey) != crypto.PubkeyToAddress(key.PublicKey) {
+			break
+		}
+	}
+
+	var digest [32]byte
+	copy(digest[:], crypto.Keccak256([]byte("test digest")))
+
+	sig, err := crypto.Sign(digest[:], key)
+	if err != nil {
+		t.Fatalf("crypto.Sign() error = %v", err)
+	}
+
+	if _, err := recoverRecoveryID(digest, sig[:32], sig[32:64], crypto.PubkeyToAddress(wrongKey.PublicKey)); err == nil {
+		t.Error("expected recoverRecoveryID to fail when no recovery id matches the expected address")
+	}
+}
@@ -0,0 +1,63 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	ata "github.com/gagliardetto/solana-go/programs/associated-token-account"
+	"github.com/gagliardetto/solana-go/programs/token"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// splAmountFromDecimal scales a human-readable SPL token amount (e.g. 10.5
+// USDC-SPL) into the raw base units the token program's Transfer
+// instruction expects, mirroring erc20AmountFromDecimal's role on the
+// Ethereum side.
+func splAmountFromDecimal(amount float64, decimals uint8) uint64 {
+	return uint64(math.Round(amount * math.Pow10(int(decimals))))
+}
+
+// buildSPLTransferInstructions builds the instruction(s) needed to transfer
+// an SPL token: a transfer instruction against the sender/recipient
+// associated token accounts, preceded by a create-ATA instruction if the
+// recipient doesn't have one yet.
+func buildSPLTransferInstructions(ctx context.Context, client *rpc.Client, mint, from, to solana.PublicKey, payer solana.PublicKey, amount uint64) ([]solana.Instruction, error) {
+	fromATA, _, err := solana.FindAssociatedTokenAddress(from, mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive sender associated token account: %w", err)
+	}
+	toATA, _, err := solana.FindAssociatedTokenAddress(to, mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive recipient associated token account: %w", err)
+	}
+
+	var instructions []solana.Instruction
+
+	if _, err := client.GetAccountInfo(ctx, toATA); err != nil {
+		createIx, err := ata.NewCreateInstructionBuilder().
+			SetPayer(payer).
+			SetWallet(to).
+			SetMint(mint).
+			Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build create-associated-token-account instruction: %w", err)
+		}
+		instructions = append(instructions, createIx)
+	}
+
+	transferIx, err := token.NewTransferInstructionBuilder().
+		SetAmount(amount).
+		SetSourceAccount(fromATA).
+		SetDestinationAccount(toATA).
+		SetOwnerAccount(from).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SPL transfer instruction: %w", err)
+	}
+	instructions = append(instructions, transferIx)
+
+	return instructions, nil
+}
@@ -0,0 +1,137 @@
+package solver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// tipBumpPercent is how much TxManager.Submit raises the tip (or gas price,
+// for legacy transactions) on each resubmit, matching the minimum bump most
+// nodes require to accept a replacement transaction at the same nonce.
+const tipBumpPercent = 20
+
+// TxManager replaces the hard-coded legacy gasPrice/gasLimit transactions
+// previously built ad hoc by ExecuteNFTContractTransaction and ListToken: it
+// prefers an EIP-1559 dynamic-fee transaction when the chain supports one,
+// reserves nonces through the shared NonceManager so concurrent calls for
+// the same sender don't race, and resubmits with a bumped tip if a
+// submission sits unconfirmed past ResubmitTimeout.
+type TxManager struct {
+	nonces *NonceManager
+
+	// ResubmitTimeout is how long Submit waits for a transaction to be
+	// mined before resubmitting it with a bumped tip.
+	ResubmitTimeout time.Duration
+}
+
+// NewTxManager returns a TxManager that reserves nonces through nonces.
+func NewTxManager(nonces *NonceManager) *TxManager {
+	return &TxManager{nonces: nonces, ResubmitTimeout: 30 * time.Second}
+}
+
+// defaultTxManager is shared by every EVM contract call that used to build
+// its own legacy transaction by hand.
+var defaultTxManager = NewTxManager(defaultNonceManager)
+
+// PrepareAuth returns TransactOpts for fromKey against entry's chain, ready
+// to pass to a bound contract's Transact. It reserves the next nonce through
+// the shared NonceManager and prefers an EIP-1559 dynamic-fee transaction,
+// querying SuggestGasTipCap against the chain's current base fee, falling
+// back to SuggestGasPrice for chains whose latest block predates London.
+func (m *TxManager) PrepareAuth(ctx context.Context, chainKey string, entry *evmChainEntry, fromKey *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	fromAddress := crypto.PubkeyToAddress(fromKey.PublicKey)
+	nonce, err := m.nonces.Reserve(ctx, chainKey, fromAddress.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve nonce: %w", err)
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(fromKey, entry.networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth: %w", err)
+	}
+	auth.Nonce = big.NewInt(int64(nonce))
+
+	if header, err := entry.client.HeaderByNumber(ctx, nil); err == nil && header.BaseFee != nil {
+		tip, err := entry.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas tip cap: %w", err)
+		}
+		auth.GasTipCap = tip
+		auth.GasFeeCap = new(big.Int).Add(tip, new(big.Int).Mul(header.BaseFee, big.NewInt(2)))
+	} else {
+		gasPrice, err := entry.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		auth.GasPrice = gasPrice
+	}
+	return auth, nil
+}
+
+// Submit calls send(auth) and waits up to m.ResubmitTimeout for the result
+// to be mined, bumping auth's tip (or gas price) by tipBumpPercent and
+// calling send again at the same nonce each time it times out, until a
+// submission is mined or ctx is done.
+func (m *TxManager) Submit(ctx context.Context, client *ethclient.Client, auth *bind.TransactOpts, send func(auth *bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	for {
+		tx, err := send(auth)
+		if err != nil {
+			return nil, err
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, m.ResubmitTimeout)
+		_, err = bind.WaitMined(waitCtx, client, tx)
+		cancel()
+		if err == nil {
+			return tx, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if auth.GasTipCap != nil {
+			auth.GasTipCap = bumpByPercent(auth.GasTipCap, tipBumpPercent)
+			auth.GasFeeCap = bumpByPercent(auth.GasFeeCap, tipBumpPercent)
+		} else if auth.GasPrice != nil {
+			auth.GasPrice = bumpByPercent(auth.GasPrice, tipBumpPercent)
+		}
+		Logger.Printf("tx manager: %s unconfirmed after %s, resubmitting with bumped fee", tx.Hash().Hex(), m.ResubmitTimeout)
+	}
+}
+
+// WaitMinedWithConfirmations waits for tx to be mined and then for at least
+// confirmations further blocks to build on top of it, so callers only treat
+// a transaction as final once it's unlikely to be reorged out.
+func (m *TxManager) WaitMinedWithConfirmations(ctx context.Context, client *ethclient.Client, tx *types.Transaction, confirmations uint64) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		latest, err := client.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %w", err)
+		}
+		if latest-receipt.BlockNumber.Uint64() >= confirmations {
+			return receipt, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollBaseDelay):
+		}
+	}
+}
+
+func bumpByPercent(v *big.Int, percent int64) *big.Int {
+	delta := new(big.Int).Div(new(big.Int).Mul(v, big.NewInt(percent)), big.NewInt(100))
+	return new(big.Int).Add(v, delta)
+}
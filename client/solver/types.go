@@ -1,5 +1,7 @@
 package solver
 
+import "encoding/json"
+
 type SolverFile struct {
 	SolverFileId string `json:"solverFileId"`
 	Owner        string `json:"owner"`
@@ -39,6 +41,27 @@ type SwapResult struct {
 	ExchangeRate float64 `json:"exchange_rate"`
 }
 
+// TokenMeta describes a swap destination token beyond native chain
+// currency: which chain it lives on, its ERC-20 contract / SPL mint
+// address, and its decimals.
+type TokenMeta struct {
+	Chain           string
+	ContractAddress string
+	Decimals        uint8
+}
+
+// knownTokens maps a swap token symbol to where/how it settles. "ETH" and
+// "SOL" settle as native transfers; everything else settles as an ERC-20
+// transfer (Ethereum) or SPL token transfer (Solana).
+var knownTokens = map[string]TokenMeta{
+	"ETH":  {Chain: "ethereum"},
+	"SOL":  {Chain: "solana"},
+	"USDC": {Chain: "ethereum", ContractAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6},
+	"WETH": {Chain: "ethereum", ContractAddress: "0xC02aaA39b223FE8D0A0e5C4f27eAD9083C756Cc2", Decimals: 18},
+	"DAI":  {Chain: "ethereum", ContractAddress: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 18},
+	"ARB":  {Chain: "arbitrum", ContractAddress: "0x912CE59144191C1204E64559FE8253a0e49E6548", Decimals: 18},
+}
+
 type TransactionPrep struct {
 	Chain       string      `json:"chain"`
 	RawTx       string      `json:"raw_tx"`
@@ -56,9 +79,37 @@ type ChainParams struct {
 	GasLimit uint64 `json:"gas_limit,omitempty"`
 	Nonce    uint64 `json:"nonce,omitempty"`
 
+	// TxType selects the Ethereum transaction envelope: "legacy" (the
+	// default) or "dynamic" for an EIP-1559 fee-market transaction. When
+	// "dynamic", MaxPriorityFeePerGas/MaxFeePerGas override the
+	// auto-estimated tip/fee cap if set.
+	TxType               string `json:"tx_type,omitempty"`
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty"`
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty"`
+
+	// Set when the transaction settles an ERC-20 transfer instead of a
+	// native value transfer: ToAddress becomes the token contract and
+	// Data carries the ABI-encoded transfer(address,uint256) calldata.
+	Data string `json:"data,omitempty"`
+
 	// Solana specific
 	RecentBlockhash string  `json:"recent_blockhash,omitempty"`
 	Lamports        float64 `json:"lamports,omitempty"`
+
+	// Extra carries backend-specific fields that don't warrant a
+	// dedicated struct field, so adding a chain doesn't require changing
+	// ChainParams itself.
+	Extra json.RawMessage `json:"extra,omitempty"`
+}
+
+// ERC20Params describes the ERC-20 token an NFT is priced/settled in,
+// resolved on-chain from the token contract rather than configured by hand.
+type ERC20Params struct {
+	TokenAddress string `json:"token_address"`
+	ChainRPCURL  string `json:"chain_rpc_url"`
+	ChainId      int64  `json:"chain_id"`
+	Symbol       string `json:"symbol,omitempty"`
+	Decimals     uint8  `json:"decimals,omitempty"`
 }
 
 type SwapResponse struct {
@@ -114,6 +165,14 @@ type ListNFTParams struct {
 	ID          int    `json:"id"`
 	Token       string `json:"token"`
 	BlobHash    string `json:"blobHash"`
+
+	// NftId is the canonical "{class_id}/{id}" identifier (Cosmos SDK
+	// ADR-043 style) for this listing, validated by the nftid package.
+	NftId string `json:"nftId"`
+
+	// ERC20 is set when the listing is priced in an ERC-20 token rather
+	// than the chain's native currency.
+	ERC20 *ERC20Params `json:"erc20,omitempty"`
 }
 
 type BlobHashParams struct {
@@ -137,13 +196,3 @@ type NFT struct {
 	BlobHash    string `json:"blobHash"`
 	NftStatus   string `json:"status"`
 }
-
-// Add response type for NFTs query
-type NFTsResponse struct {
-	Data struct {
-		NFTs map[string]NFT `json:"nfts"`
-	} `json:"data"`
-	Errors []struct {
-		Message string `json:"message"`
-	} `json:"errors,omitempty"`
-}